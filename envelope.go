@@ -5,8 +5,278 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidEnvelopeCode defines an error that denotes that a
+	// code string could not be decomposed by a code formatter.
+	ErrInvalidEnvelopeCode = fmt.Errorf("invalid envelope error code")
+
+	// ErrUnknownEnvelopeCodeFormat defines an error that denotes that a
+	// requested code format is not recognized.
+	ErrUnknownEnvelopeCodeFormat = fmt.Errorf("unknown envelope error code format")
 )
 
+func errInvalidEnvelopeCode(
+	code string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrInvalidEnvelopeCode, code, ctx...)
+}
+
+func errUnknownEnvelopeCodeFormat(
+	format string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrUnknownEnvelopeCodeFormat, format, ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// envelope code
+// ----------------------------------------------------------------------------
+
+const (
+	// EnvelopeCodeFormatStd defines the name of the standard
+	// "s:%d.e:%d.p:%d.c:%d" code format.
+	EnvelopeCodeFormatStd = "std"
+
+	// EnvelopeCodeFormatPadded defines the name of the zero-padded
+	// "SVC-ENDPOINT-PARAM-CODE" code format.
+	EnvelopeCodeFormatPadded = "padded"
+)
+
+// EnvelopeCode defines the decomposed sections of an envelope error code.
+type EnvelopeCode struct {
+	Service  int
+	Endpoint int
+	Param    int
+	Error    string
+}
+
+// EnvelopeCodeFormatter defines the interface of an instance able to
+// compose an envelope error code and to decompose it back to its sections.
+type EnvelopeCodeFormatter interface {
+	Format(code EnvelopeCode) string
+	Parse(code string) (*EnvelopeCode, error)
+}
+
+// EnvelopeCodeFormat defines the globally used code formatter when
+// no formatter was assigned to the envelope error.
+var EnvelopeCodeFormat EnvelopeCodeFormatter = NewEnvelopeStdCodeFormatter()
+
+// NewEnvelopeCodeFormatter instantiates a code formatter of the requested
+// format. The padded format will use the given separator and section
+// widths (service, endpoint, param and error).
+func NewEnvelopeCodeFormatter(
+	format string,
+	separator string,
+	widths ...int,
+) (EnvelopeCodeFormatter, error) {
+	switch strings.ToLower(format) {
+	case "", EnvelopeCodeFormatStd:
+		return NewEnvelopeStdCodeFormatter(), nil
+	case EnvelopeCodeFormatPadded:
+		return NewEnvelopePaddedCodeFormatter(separator, widths...), nil
+	}
+	return nil, errUnknownEnvelopeCodeFormat(format)
+}
+
+// ParseEnvelopeCode will decompose the given code with the globally
+// defined code formatter.
+func ParseEnvelopeCode(
+	code string,
+) (*EnvelopeCode, error) {
+	return EnvelopeCodeFormat.Parse(code)
+}
+
+// ----------------------------------------------------------------------------
+// envelope std code formatter
+// ----------------------------------------------------------------------------
+
+// EnvelopeStdCodeFormatter defines the default code formatter that
+// compose the codes in the "s:%d.e:%d.p:%d.c:%d" layout, omitting any
+// section with a zero value.
+type EnvelopeStdCodeFormatter struct{}
+
+var _ EnvelopeCodeFormatter = &EnvelopeStdCodeFormatter{}
+
+// NewEnvelopeStdCodeFormatter instantiates a new standard code formatter.
+func NewEnvelopeStdCodeFormatter() *EnvelopeStdCodeFormatter {
+	return &EnvelopeStdCodeFormatter{}
+}
+
+// Format will compose the code string from the given code sections.
+func (EnvelopeStdCodeFormatter) Format(
+	code EnvelopeCode,
+) string {
+	cb := strings.Builder{}
+	// compose the service section of the code
+	if code.Service != 0 {
+		cb.WriteString(fmt.Sprintf("s:%d", code.Service))
+	}
+	// compose the endpoint section of the code
+	if code.Endpoint != 0 {
+		if cb.Len() != 0 {
+			cb.WriteString(".")
+		}
+		cb.WriteString(fmt.Sprintf("e:%d", code.Endpoint))
+	}
+	// compose the param section of the code
+	if code.Param != 0 {
+		if cb.Len() != 0 {
+			cb.WriteString(".")
+		}
+		cb.WriteString(fmt.Sprintf("p:%d", code.Param))
+	}
+	// compose the error section of the code
+	if code.Error != "" {
+		if cb.Len() != 0 {
+			cb.WriteString(".")
+		}
+
+		if i, err := strconv.Atoi(code.Error); err != nil {
+			cb.WriteString(code.Error)
+		} else {
+			cb.WriteString(fmt.Sprintf("c:%d", i))
+		}
+	}
+	return cb.String()
+}
+
+// Parse will decompose the given code string into its sections.
+func (EnvelopeStdCodeFormatter) Parse(
+	code string,
+) (*EnvelopeCode, error) {
+	result := &EnvelopeCode{}
+	sections := strings.Split(code, ".")
+	for i, section := range sections {
+		// check for a numeric prefixed section
+		prefix, value, found := strings.Cut(section, ":")
+		if found {
+			if n, e := strconv.Atoi(value); e == nil {
+				switch prefix {
+				case "s":
+					result.Service = n
+					continue
+				case "e":
+					result.Endpoint = n
+					continue
+				case "p":
+					result.Param = n
+					continue
+				case "c":
+					if i != len(sections)-1 {
+						return nil, errInvalidEnvelopeCode(code)
+					}
+					result.Error = strconv.Itoa(n)
+					continue
+				}
+			}
+		}
+		// any non-prefixed section marks the start of a textual error
+		result.Error = strings.Join(sections[i:], ".")
+		break
+	}
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// envelope padded code formatter
+// ----------------------------------------------------------------------------
+
+// EnvelopePaddedCodeFormatter defines a code formatter that compose
+// the codes in a "SVC-ENDPOINT-PARAM-CODE" layout, where all the numeric
+// sections are zero-padded to the configured width.
+type EnvelopePaddedCodeFormatter struct {
+	Separator     string
+	ServiceWidth  int
+	EndpointWidth int
+	ParamWidth    int
+	ErrorWidth    int
+}
+
+var _ EnvelopeCodeFormatter = &EnvelopePaddedCodeFormatter{}
+
+// NewEnvelopePaddedCodeFormatter instantiates a new padded code formatter
+// with the given separator and the optional service, endpoint, param and
+// error section widths. Missing separator defaults to "-", and missing
+// widths defaults to 3 digits for the service, endpoint and error, and 2
+// digits for the param.
+func NewEnvelopePaddedCodeFormatter(
+	separator string,
+	widths ...int,
+) *EnvelopePaddedCodeFormatter {
+	if separator == "" {
+		separator = "-"
+	}
+	w := []int{3, 3, 2, 3}
+	for i, width := range widths {
+		if i < len(w) && width > 0 {
+			w[i] = width
+		}
+	}
+	return &EnvelopePaddedCodeFormatter{
+		Separator:     separator,
+		ServiceWidth:  w[0],
+		EndpointWidth: w[1],
+		ParamWidth:    w[2],
+		ErrorWidth:    w[3],
+	}
+}
+
+// Format will compose the code string from the given code sections.
+func (f EnvelopePaddedCodeFormatter) Format(
+	code EnvelopeCode,
+) string {
+	// textual errors are not padded
+	e := code.Error
+	if i, err := strconv.Atoi(code.Error); err == nil {
+		e = fmt.Sprintf("%0*d", f.ErrorWidth, i)
+	}
+	return strings.Join([]string{
+		fmt.Sprintf("%0*d", f.ServiceWidth, code.Service),
+		fmt.Sprintf("%0*d", f.EndpointWidth, code.Endpoint),
+		fmt.Sprintf("%0*d", f.ParamWidth, code.Param),
+		e,
+	}, f.Separator)
+}
+
+// Parse will decompose the given code string into its sections.
+func (f EnvelopePaddedCodeFormatter) Parse(
+	code string,
+) (*EnvelopeCode, error) {
+	sections := strings.SplitN(code, f.Separator, 4)
+	if len(sections) != 4 {
+		return nil, errInvalidEnvelopeCode(code)
+	}
+	// parse the numeric sections
+	var numbers [3]int
+	for i := range numbers {
+		n, e := strconv.Atoi(sections[i])
+		if e != nil {
+			return nil, errInvalidEnvelopeCode(code)
+		}
+		numbers[i] = n
+	}
+	// remove the error section padding if numeric
+	e := sections[3]
+	if n, err := strconv.Atoi(e); err == nil {
+		e = strconv.Itoa(n)
+	}
+	return &EnvelopeCode{
+		Service:  numbers[0],
+		Endpoint: numbers[1],
+		Param:    numbers[2],
+		Error:    e,
+	}, nil
+}
+
 // ----------------------------------------------------------------------------
 // envelope status error
 // ----------------------------------------------------------------------------
@@ -21,6 +291,10 @@ type EnvelopeStatusError struct {
 	Error    string `json:"-" xml:"-"`
 	Code     string `json:"code" xml:"code"`
 	Message  string `json:"message" xml:"message"`
+	Field    string `json:"field,omitempty" xml:"-"`
+	Path     string `json:"path,omitempty" xml:"-"`
+
+	formatter EnvelopeCodeFormatter
 }
 
 // NewEnvelopeStatusError instantiates a new error instance.
@@ -74,6 +348,31 @@ func (e *EnvelopeStatusError) SetMessage(
 	return e
 }
 
+// SetField assigns the name of the request field related to the error.
+func (e *EnvelopeStatusError) SetField(
+	field string,
+) *EnvelopeStatusError {
+	e.Field = field
+	return e
+}
+
+// SetPath assigns the path of the request field related to the error.
+func (e *EnvelopeStatusError) SetPath(
+	path string,
+) *EnvelopeStatusError {
+	e.Path = path
+	return e
+}
+
+// SetFormatter assigns the code formatter used to compose the error
+// code. A nil formatter will make the error use the global formatter.
+func (e *EnvelopeStatusError) SetFormatter(
+	formatter EnvelopeCodeFormatter,
+) *EnvelopeStatusError {
+	e.formatter = formatter
+	return e.compose()
+}
+
 // GetCode retrieves the composed code of the error
 func (e *EnvelopeStatusError) GetCode() string {
 	return e.Code
@@ -84,40 +383,29 @@ func (e *EnvelopeStatusError) GetMessage() string {
 	return e.Message
 }
 
-func (e *EnvelopeStatusError) compose() *EnvelopeStatusError {
-	cb := strings.Builder{}
-	// compose the service section of the code
-	if e.Service != 0 {
-		cb.WriteString(fmt.Sprintf("s:%d", e.Service))
-	}
-	// compose the endpoint section of the code
-	if e.Endpoint != 0 {
-		if cb.Len() != 0 {
-			cb.WriteString(".")
-		}
-		cb.WriteString(fmt.Sprintf("e:%d", e.Endpoint))
-	}
-	// compose the param section of the code
-	if e.Param != 0 {
-		if cb.Len() != 0 {
-			cb.WriteString(".")
-		}
-		cb.WriteString(fmt.Sprintf("p:%d", e.Param))
-	}
-	// compose the error section of the code
-	if e.Error != "" {
-		if cb.Len() != 0 {
-			cb.WriteString(".")
-		}
+// GetField retrieves the name of the request field related to the error
+func (e *EnvelopeStatusError) GetField() string {
+	return e.Field
+}
 
-		if i, err := strconv.Atoi(e.Error); err != nil {
-			cb.WriteString(e.Error)
-		} else {
-			cb.WriteString(fmt.Sprintf("c:%d", i))
-		}
+// GetPath retrieves the path of the request field related to the error
+func (e *EnvelopeStatusError) GetPath() string {
+	return e.Path
+}
+
+func (e *EnvelopeStatusError) compose() *EnvelopeStatusError {
+	// select the formatter used to compose the code
+	formatter := e.formatter
+	if formatter == nil {
+		formatter = EnvelopeCodeFormat
 	}
-	// assign the written code string to the error code structure parameter
-	e.Code = cb.String()
+	// assign the formatted code string to the error code structure parameter
+	e.Code = formatter.Format(EnvelopeCode{
+		Service:  e.Service,
+		Endpoint: e.Endpoint,
+		Param:    e.Param,
+		Error:    e.Error,
+	})
 	return e
 }
 
@@ -140,14 +428,19 @@ func (s EnvelopeStatusErrorList) MarshalXML(
 	for _, v := range s {
 		// create the iterated error starting tag name
 		name := xml.Name{Space: "", Local: "error"}
-		// encode the error instance tag with the code and message attributes
-		_ = e.EncodeToken(xml.StartElement{
-			Name: name,
-			Attr: []xml.Attr{
-				{Name: xml.Name{Local: "code"}, Value: v.Code},
-				{Name: xml.Name{Local: "message"}, Value: v.Message},
-			},
-		})
+		// encode the error instance tag with the code, message and
+		// optional field and path attributes
+		attrs := []xml.Attr{
+			{Name: xml.Name{Local: "code"}, Value: v.Code},
+			{Name: xml.Name{Local: "message"}, Value: v.Message},
+		}
+		if v.Field != "" {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "field"}, Value: v.Field})
+		}
+		if v.Path != "" {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "path"}, Value: v.Path})
+		}
+		_ = e.EncodeToken(xml.StartElement{Name: name, Attr: attrs})
 		// encode the terminating error tag
 		_ = e.EncodeToken(xml.EndElement{Name: name})
 	}
@@ -157,6 +450,47 @@ func (s EnvelopeStatusErrorList) MarshalXML(
 	return nil
 }
 
+// UnmarshalXML deserialize the error list from a xml string
+func (s *EnvelopeStatusErrorList) UnmarshalXML(
+	d *xml.Decoder,
+	start xml.StartElement,
+) error {
+	list := EnvelopeStatusErrorList{}
+	for {
+		// read the next list token
+		token, e := d.Token()
+		if e != nil {
+			return e
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			// store the error instance code, message, field and path attributes
+			v := &EnvelopeStatusError{}
+			for _, attr := range t.Attr {
+				switch attr.Name.Local {
+				case "code":
+					v.Code = attr.Value
+				case "message":
+					v.Message = attr.Value
+				case "field":
+					v.Field = attr.Value
+				case "path":
+					v.Path = attr.Value
+				}
+			}
+			list = append(list, v)
+			// discard the error instance content
+			if e := d.Skip(); e != nil {
+				return e
+			}
+		case xml.EndElement:
+			// terminate on the list ending tag
+			*s = list
+			return nil
+		}
+	}
+}
+
 // ----------------------------------------------------------------------------
 // envelope status
 // ----------------------------------------------------------------------------
@@ -185,6 +519,16 @@ func (s *EnvelopeStatus) AddError(
 	return s
 }
 
+// SetFormatter assign a code formatter to all stored error.
+func (s *EnvelopeStatus) SetFormatter(
+	formatter EnvelopeCodeFormatter,
+) *EnvelopeStatus {
+	for i := range s.Errors {
+		s.Errors[i] = s.Errors[i].SetFormatter(formatter)
+	}
+	return s
+}
+
 // SetService assign a service code to all stored error.
 func (s *EnvelopeStatus) SetService(
 	val int,
@@ -271,6 +615,52 @@ func NewEnvelopeListReport(
 	}
 }
 
+// ----------------------------------------------------------------------------
+// envelope meta
+// ----------------------------------------------------------------------------
+
+// EnvelopeMetaWarning defines the structure of a non-fatal warning
+// reported in the envelope meta information.
+type EnvelopeMetaWarning struct {
+	Code    string `json:"code" xml:"code,attr"`
+	Message string `json:"message" xml:"message,attr"`
+}
+
+// NewEnvelopeMetaWarning instantiates a new meta warning instance.
+func NewEnvelopeMetaWarning(
+	code any,
+	msg string,
+) *EnvelopeMetaWarning {
+	return &EnvelopeMetaWarning{
+		Code:    fmt.Sprintf("%v", code),
+		Message: msg,
+	}
+}
+
+// EnvelopeMeta defines the structure of the response meta information
+// section, holding the request processing information and the list of
+// non-fatal warnings.
+type EnvelopeMeta struct {
+	RequestID  string                 `json:"requestId,omitempty" xml:"requestId,omitempty"`
+	ServerTime string                 `json:"serverTime,omitempty" xml:"serverTime,omitempty"`
+	Duration   float64                `json:"duration,omitempty" xml:"duration,omitempty"`
+	Version    string                 `json:"version,omitempty" xml:"version,omitempty"`
+	Warnings   []*EnvelopeMetaWarning `json:"warnings,omitempty" xml:"warnings>warning,omitempty"`
+}
+
+// NewEnvelopeMeta instantiates a new empty response meta structure.
+func NewEnvelopeMeta() *EnvelopeMeta {
+	return &EnvelopeMeta{}
+}
+
+// AddWarning append a new warning to the meta warning list.
+func (m *EnvelopeMeta) AddWarning(
+	w *EnvelopeMetaWarning,
+) *EnvelopeMeta {
+	m.Warnings = append(m.Warnings, w)
+	return m
+}
+
 // ----------------------------------------------------------------------------
 // envelope
 // ----------------------------------------------------------------------------
@@ -280,6 +670,7 @@ type Envelope struct {
 	XMLName    xml.Name            `json:"-" xml:"envelope"`
 	StatusCode int                 `json:"-" xml:"-"`
 	Status     *EnvelopeStatus     `json:"status" xml:"status"`
+	Meta       *EnvelopeMeta       `json:"meta,omitempty" xml:"meta,omitempty"`
 	ListReport *EnvelopeListReport `json:"report,omitempty" xml:"report,omitempty"`
 	Data       interface{}         `json:"data,omitempty" xml:"data,omitempty"`
 }
@@ -294,6 +685,7 @@ func NewEnvelope(
 	env := &Envelope{
 		StatusCode: statusCode,
 		Status:     NewEnvelopeStatus(),
+		Meta:       nil,
 		ListReport: nil,
 		Data:       data,
 	}
@@ -309,6 +701,14 @@ func (s *Envelope) GetStatusCode() int {
 	return s.StatusCode
 }
 
+// SetFormatter assign the code formatter to all stored error codes
+func (s *Envelope) SetFormatter(
+	formatter EnvelopeCodeFormatter,
+) *Envelope {
+	s.Status = s.Status.SetFormatter(formatter)
+	return s
+}
+
 // SetService assign the service identifier to all stored error codes
 func (s *Envelope) SetService(
 	val int,
@@ -333,6 +733,27 @@ func (s *Envelope) SetListReport(
 	return s
 }
 
+// SetMeta assign the meta information to the envelope
+func (s *Envelope) SetMeta(
+	meta *EnvelopeMeta,
+) *Envelope {
+	s.Meta = meta
+	return s
+}
+
+// AddWarning add a new non-fatal warning to the envelope meta
+// information. Warnings, unlike errors, will not flag the envelope
+// status as unsuccessful.
+func (s *Envelope) AddWarning(
+	w *EnvelopeMetaWarning,
+) *Envelope {
+	if s.Meta == nil {
+		s.Meta = NewEnvelopeMeta()
+	}
+	s.Meta = s.Meta.AddWarning(w)
+	return s
+}
+
 // AddError add a new error to the response envelope instance
 func (s *Envelope) AddError(
 	e *EnvelopeStatusError,
@@ -340,3 +761,163 @@ func (s *Envelope) AddError(
 	s.Status = s.Status.AddError(e)
 	return s
 }
+
+// ----------------------------------------------------------------------------
+// typed envelope
+// ----------------------------------------------------------------------------
+
+// EnvelopeConverter defines the interface of an instance that can be
+// converted into a response envelope, allowing it to be rendered by the
+// envelope middleware.
+type EnvelopeConverter interface {
+	Envelope() *Envelope
+}
+
+// TypedEnvelope identifies the structure of a response structured format
+// with a typed data section.
+type TypedEnvelope[T any] struct {
+	XMLName    xml.Name            `json:"-" xml:"envelope"`
+	StatusCode int                 `json:"-" xml:"-"`
+	Status     *EnvelopeStatus     `json:"status" xml:"status"`
+	Meta       *EnvelopeMeta       `json:"meta,omitempty" xml:"meta,omitempty"`
+	ListReport *EnvelopeListReport `json:"report,omitempty" xml:"report,omitempty"`
+	Data       T                   `json:"data,omitempty" xml:"data,omitempty"`
+}
+
+var _ EnvelopeConverter = &TypedEnvelope[int]{}
+
+// NewTypedEnvelope instantiates a new response envelope structure
+// with a typed data section.
+func NewTypedEnvelope[T any](
+	statusCode int,
+	data T,
+	listReport ...*EnvelopeListReport,
+) *TypedEnvelope[T] {
+	// initialize the envelope structure
+	env := &TypedEnvelope[T]{
+		StatusCode: statusCode,
+		Status:     NewEnvelopeStatus(),
+		Meta:       nil,
+		ListReport: nil,
+		Data:       data,
+	}
+	// assign the list report if given as argument
+	if len(listReport) > 0 && listReport[0] != nil {
+		env.ListReport = listReport[0]
+	}
+	return env
+}
+
+// NewTypedListEnvelope instantiates a new response envelope structure
+// with a typed list data section and the list report information.
+func NewTypedListEnvelope[T any](
+	statusCode int,
+	data []T,
+	listReport *EnvelopeListReport,
+) *TypedEnvelope[[]T] {
+	return NewTypedEnvelope[[]T](statusCode, data, listReport)
+}
+
+// TypedEnvelopeFrom will create a typed envelope from the given envelope
+// by type checking the stored data section.
+func TypedEnvelopeFrom[T any](
+	env *Envelope,
+) (*TypedEnvelope[T], error) {
+	// check the envelope argument reference
+	if env == nil {
+		return nil, errNilPointer("env")
+	}
+	// type check the envelope data section
+	var data T
+	if env.Data != nil {
+		v, ok := env.Data.(T)
+		if !ok {
+			return nil, errConversion(env.Data, fmt.Sprintf("%T", data))
+		}
+		data = v
+	}
+	return &TypedEnvelope[T]{
+		StatusCode: env.StatusCode,
+		Status:     env.Status,
+		Meta:       env.Meta,
+		ListReport: env.ListReport,
+		Data:       data,
+	}, nil
+}
+
+// Envelope will convert the typed envelope into an untyped envelope
+// sharing the same status, meta and list report information.
+func (s *TypedEnvelope[T]) Envelope() *Envelope {
+	return &Envelope{
+		StatusCode: s.StatusCode,
+		Status:     s.Status,
+		Meta:       s.Meta,
+		ListReport: s.ListReport,
+		Data:       s.Data,
+	}
+}
+
+// GetStatusCode returned the stored enveloped response status code
+func (s *TypedEnvelope[T]) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// SetFormatter assign the code formatter to all stored error codes
+func (s *TypedEnvelope[T]) SetFormatter(
+	formatter EnvelopeCodeFormatter,
+) *TypedEnvelope[T] {
+	s.Status = s.Status.SetFormatter(formatter)
+	return s
+}
+
+// SetService assign the service identifier to all stored error codes
+func (s *TypedEnvelope[T]) SetService(
+	val int,
+) *TypedEnvelope[T] {
+	s.Status = s.Status.SetService(val)
+	return s
+}
+
+// SetEndpoint assign the endpoint identifier to all stored error codes
+func (s *TypedEnvelope[T]) SetEndpoint(
+	val int,
+) *TypedEnvelope[T] {
+	s.Status = s.Status.SetEndpoint(val)
+	return s
+}
+
+// SetListReport assign the list report to the envelope
+func (s *TypedEnvelope[T]) SetListReport(
+	listReport *EnvelopeListReport,
+) *TypedEnvelope[T] {
+	s.ListReport = listReport
+	return s
+}
+
+// SetMeta assign the meta information to the envelope
+func (s *TypedEnvelope[T]) SetMeta(
+	meta *EnvelopeMeta,
+) *TypedEnvelope[T] {
+	s.Meta = meta
+	return s
+}
+
+// AddWarning add a new non-fatal warning to the envelope meta
+// information.
+func (s *TypedEnvelope[T]) AddWarning(
+	w *EnvelopeMetaWarning,
+) *TypedEnvelope[T] {
+	if s.Meta == nil {
+		s.Meta = NewEnvelopeMeta()
+	}
+	s.Meta = s.Meta.AddWarning(w)
+	return s
+}
+
+// AddError add a new error to the response envelope instance
+func (s *TypedEnvelope[T]) AddError(
+	e *EnvelopeStatusError,
+) *TypedEnvelope[T] {
+	s.Status = s.Status.AddError(e)
+	return s
+}
@@ -0,0 +1,339 @@
+package sapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// EnvelopeContainerID defines a base id of all other envelope
+	// module instances registered in the application container.
+	EnvelopeContainerID = slate.ContainerID + ".envelope"
+
+	// EnvelopeCatalogContainerID defines the id to be used as the
+	// container registration id of the error code catalog.
+	EnvelopeCatalogContainerID = EnvelopeContainerID + ".catalog"
+
+	// EnvelopeCatalogProviderTag defines the tag to be assigned to all
+	// the catalog entry providers registered in the application container.
+	EnvelopeCatalogProviderTag = EnvelopeCatalogContainerID + ".providers"
+
+	// EnvelopeCatalogAllProvidersContainerID defines the id to be used as
+	// the container registration id of the list of all catalog providers.
+	EnvelopeCatalogAllProvidersContainerID = EnvelopeCatalogProviderTag + ".all"
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrDuplicateEnvelopeCatalogEntry defines an error that denotes
+	// that an error code was registered more than once in the catalog.
+	ErrDuplicateEnvelopeCatalogEntry = fmt.Errorf("duplicate envelope catalog error code")
+
+	// ErrEnvelopeCatalogEntryNotFound defines an error that denotes
+	// that a requested error code was not registered in the catalog.
+	ErrEnvelopeCatalogEntryNotFound = fmt.Errorf("envelope catalog error code not found")
+)
+
+func errDuplicateEnvelopeCatalogEntry(
+	code string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrDuplicateEnvelopeCatalogEntry, code, ctx...)
+}
+
+func errEnvelopeCatalogEntryNotFound(
+	code string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrEnvelopeCatalogEntryNotFound, code, ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// envelope catalog entry
+// ----------------------------------------------------------------------------
+
+// EnvelopeCatalogEntry defines the information stored in the catalog
+// regarding a single error code.
+type EnvelopeCatalogEntry struct {
+	Error       string `json:"error"`
+	Message     string `json:"message"`
+	StatusCode  int    `json:"status"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewEnvelopeCatalogEntry instantiates a new catalog entry. If no
+// status code is given, the entry will default to a bad request status.
+func NewEnvelopeCatalogEntry(
+	e any,
+	msg string,
+	statusCode int,
+	description string,
+) *EnvelopeCatalogEntry {
+	if statusCode == 0 {
+		statusCode = http.StatusBadRequest
+	}
+	return &EnvelopeCatalogEntry{
+		Error:       fmt.Sprintf("%v", e),
+		Message:     msg,
+		StatusCode:  statusCode,
+		Description: description,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// envelope catalog provider
+// ----------------------------------------------------------------------------
+
+// EnvelopeCatalogProvider defines an interface to an instance that
+// is able to supply error code entries to the application catalog.
+type EnvelopeCatalogProvider interface {
+	Errors() []*EnvelopeCatalogEntry
+}
+
+// ----------------------------------------------------------------------------
+// envelope catalog
+// ----------------------------------------------------------------------------
+
+// EnvelopeCatalog defines a registry of the error codes that a service
+// can return, used to build the envelope errors and to document them.
+type EnvelopeCatalog struct {
+	entries map[string]*EnvelopeCatalogEntry
+}
+
+// NewEnvelopeCatalog instantiates a new error code catalog populated
+// with the entries supplied by the given providers.
+func NewEnvelopeCatalog(
+	providers []EnvelopeCatalogProvider,
+) (*EnvelopeCatalog, error) {
+	c := &EnvelopeCatalog{
+		entries: map[string]*EnvelopeCatalogEntry{},
+	}
+	// register all the entries of all the providers
+	for _, provider := range providers {
+		for _, entry := range provider.Errors() {
+			if e := c.Add(entry); e != nil {
+				return nil, e
+			}
+		}
+	}
+	return c, nil
+}
+
+// Add will register a new entry in the catalog, rejecting any
+// entry with an already registered error code.
+func (c *EnvelopeCatalog) Add(
+	entry *EnvelopeCatalogEntry,
+) error {
+	// check the entry argument reference
+	if entry == nil {
+		return errNilPointer("entry")
+	}
+	// check for a duplicate code registration
+	if _, ok := c.entries[entry.Error]; ok {
+		return errDuplicateEnvelopeCatalogEntry(entry.Error)
+	}
+	c.entries[entry.Error] = entry
+	return nil
+}
+
+// Has will check if an error code is registered in the catalog.
+func (c *EnvelopeCatalog) Has(
+	e any,
+) bool {
+	_, ok := c.entries[fmt.Sprintf("%v", e)]
+	return ok
+}
+
+// Get will retrieve the catalog entry of the requested error code.
+func (c *EnvelopeCatalog) Get(
+	e any,
+) (*EnvelopeCatalogEntry, error) {
+	code := fmt.Sprintf("%v", e)
+	entry, ok := c.entries[code]
+	if !ok {
+		return nil, errEnvelopeCatalogEntryNotFound(code)
+	}
+	return entry, nil
+}
+
+// Entries will retrieve the list of all registered entries sorted
+// by the error code.
+func (c *EnvelopeCatalog) Entries() []*EnvelopeCatalogEntry {
+	var entries []*EnvelopeCatalogEntry
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return envelopeCatalogLess(entries[i].Error, entries[j].Error)
+	})
+	return entries
+}
+
+// Error will create a new envelope status error from the catalog entry
+// of the requested error code. If arguments are given, they will be
+// used to format the entry default message.
+func (c *EnvelopeCatalog) Error(
+	e any,
+	args ...interface{},
+) (*EnvelopeStatusError, error) {
+	entry, err := c.Get(e)
+	if err != nil {
+		return nil, err
+	}
+	msg := entry.Message
+	if len(args) != 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return NewEnvelopeStatusError(entry.Error, msg), nil
+}
+
+// Envelope will create a new response envelope with the catalog entry
+// status code and the error of the requested error code.
+func (c *EnvelopeCatalog) Envelope(
+	e any,
+	args ...interface{},
+) (*Envelope, error) {
+	entry, err := c.Get(e)
+	if err != nil {
+		return nil, err
+	}
+	statusError, _ := c.Error(e, args...)
+	return NewEnvelope(entry.StatusCode, nil).AddError(statusError), nil
+}
+
+// JSON will export the catalog entries as a JSON document.
+func (c *EnvelopeCatalog) JSON() ([]byte, error) {
+	entries := c.Entries()
+	if entries == nil {
+		entries = []*EnvelopeCatalogEntry{}
+	}
+	return json.MarshalIndent(entries, "", "  ")
+}
+
+// Markdown will export the catalog entries as a markdown table.
+func (c *EnvelopeCatalog) Markdown() string {
+	b := strings.Builder{}
+	b.WriteString("| Code | Status | Message | Description |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, entry := range c.Entries() {
+		b.WriteString(fmt.Sprintf(
+			"| %s | %d | %s | %s |\n",
+			NewEnvelopeStatusError(entry.Error, "").GetCode(),
+			entry.StatusCode,
+			envelopeCatalogMarkdownEscape(entry.Message),
+			envelopeCatalogMarkdownEscape(entry.Description),
+		))
+	}
+	return b.String()
+}
+
+func envelopeCatalogLess(
+	a, b string,
+) bool {
+	// numeric codes are sorted by their value and placed
+	// before the textual codes
+	ia, ea := strconv.Atoi(a)
+	ib, eb := strconv.Atoi(b)
+	switch {
+	case ea == nil && eb == nil && ia != ib:
+		return ia < ib
+	case ea == nil && eb != nil:
+		return true
+	case ea != nil && eb == nil:
+		return false
+	}
+	return a < b
+}
+
+func envelopeCatalogMarkdownEscape(
+	val string,
+) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(val)
+}
+
+// ----------------------------------------------------------------------------
+// envelope catalog service register
+// ----------------------------------------------------------------------------
+
+// EnvelopeCatalogServiceRegister defines the error code catalog provider
+// to be used on the application initialization to register the catalog.
+type EnvelopeCatalogServiceRegister struct {
+	slate.ServiceRegister
+}
+
+var _ slate.ServiceProvider = &EnvelopeCatalogServiceRegister{}
+
+// NewEnvelopeCatalogServiceRegister will generate a new registry instance
+func NewEnvelopeCatalogServiceRegister(
+	app ...*slate.App,
+) *EnvelopeCatalogServiceRegister {
+	return &EnvelopeCatalogServiceRegister{
+		ServiceRegister: *slate.NewServiceRegister(app...),
+	}
+}
+
+// Provide will register the error code catalog instances in the
+// application container.
+func (sr EnvelopeCatalogServiceRegister) Provide(
+	container *slate.ServiceContainer,
+) error {
+	// check container argument reference
+	if container == nil {
+		return errNilPointer("container")
+	}
+	_ = container.Add(EnvelopeCatalogAllProvidersContainerID, sr.getProviders(container))
+	_ = container.Add(EnvelopeCatalogContainerID, NewEnvelopeCatalog)
+	return nil
+}
+
+// Boot will populate the catalog, rejecting any duplicate
+// error code registration.
+func (sr EnvelopeCatalogServiceRegister) Boot(
+	container *slate.ServiceContainer,
+) error {
+	// check container argument reference
+	if container == nil {
+		return errNilPointer("container")
+	}
+	// retrieve the catalog entry
+	entry, e := container.Get(EnvelopeCatalogContainerID)
+	if e != nil {
+		return e
+	}
+	// validate the retrieved entry type
+	if _, ok := entry.(*EnvelopeCatalog); !ok {
+		return errConversion(entry, "*EnvelopeCatalog")
+	}
+	return nil
+}
+
+func (EnvelopeCatalogServiceRegister) getProviders(
+	container *slate.ServiceContainer,
+) func() []EnvelopeCatalogProvider {
+	return func() []EnvelopeCatalogProvider {
+		// retrieve all the catalog providers
+		var providers []EnvelopeCatalogProvider
+		entries, _ := container.Tag(EnvelopeCatalogProviderTag)
+		for _, entry := range entries {
+			// type check the retrieved service
+			provider, ok := entry.(EnvelopeCatalogProvider)
+			if ok {
+				providers = append(providers, provider)
+			}
+		}
+		return providers
+	}
+}
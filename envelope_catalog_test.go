@@ -0,0 +1,394 @@
+package sapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/happyhippyhippo/slate"
+)
+
+type testEnvelopeCatalogProvider struct {
+	entries []*EnvelopeCatalogEntry
+}
+
+func (p testEnvelopeCatalogProvider) Errors() []*EnvelopeCatalogEntry {
+	return p.entries
+}
+
+func Test_envelope_catalog_err(t *testing.T) {
+	t.Run("errDuplicateEnvelopeCatalogEntry", func(t *testing.T) {
+		arg := "dummy argument"
+		message := "dummy argument : duplicate envelope catalog error code"
+
+		if e := errDuplicateEnvelopeCatalogEntry(arg); !errors.Is(e, ErrDuplicateEnvelopeCatalogEntry) {
+			t.Errorf("error not a instance of ErrDuplicateEnvelopeCatalogEntry")
+		} else if e.Error() != message {
+			t.Errorf("error message (%v) not same as expected (%v)", e, message)
+		}
+	})
+
+	t.Run("errEnvelopeCatalogEntryNotFound", func(t *testing.T) {
+		arg := "dummy argument"
+		message := "dummy argument : envelope catalog error code not found"
+
+		if e := errEnvelopeCatalogEntryNotFound(arg); !errors.Is(e, ErrEnvelopeCatalogEntryNotFound) {
+			t.Errorf("error not a instance of ErrEnvelopeCatalogEntryNotFound")
+		} else if e.Error() != message {
+			t.Errorf("error message (%v) not same as expected (%v)", e, message)
+		}
+	})
+}
+
+func Test_EnvelopeCatalogEntry(t *testing.T) {
+	t.Run("NewEnvelopeCatalogEntry", func(t *testing.T) {
+		t.Run("construct", func(t *testing.T) {
+			expected := &EnvelopeCatalogEntry{
+				Error:       "123",
+				Message:     "message",
+				StatusCode:  http.StatusConflict,
+				Description: "description",
+			}
+
+			if check := NewEnvelopeCatalogEntry(123, "message", http.StatusConflict, "description"); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("default to bad request status", func(t *testing.T) {
+			if check := NewEnvelopeCatalogEntry(123, "message", 0, ""); check.StatusCode != http.StatusBadRequest {
+				t.Errorf("(%v) when expecting (%v)", check.StatusCode, http.StatusBadRequest)
+			}
+		})
+	})
+}
+
+func Test_EnvelopeCatalog(t *testing.T) {
+	t.Run("NewEnvelopeCatalog", func(t *testing.T) {
+		t.Run("construct without providers", func(t *testing.T) {
+			if sut, e := NewEnvelopeCatalog(nil); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if sut == nil {
+				t.Error("didn't returned a valid reference")
+			} else if len(sut.Entries()) != 0 {
+				t.Errorf("unexpected entries (%v)", sut.Entries())
+			}
+		})
+
+		t.Run("construct with provider entries", func(t *testing.T) {
+			provider := testEnvelopeCatalogProvider{entries: []*EnvelopeCatalogEntry{
+				NewEnvelopeCatalogEntry(1, "message 1", 0, ""),
+				NewEnvelopeCatalogEntry(2, "message 2", 0, ""),
+			}}
+
+			if sut, e := NewEnvelopeCatalog([]EnvelopeCatalogProvider{provider}); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !sut.Has(1) || !sut.Has(2) {
+				t.Error("didn't registered the provider entries")
+			}
+		})
+
+		t.Run("reject duplicate codes between providers", func(t *testing.T) {
+			provider1 := testEnvelopeCatalogProvider{entries: []*EnvelopeCatalogEntry{NewEnvelopeCatalogEntry(1, "message 1", 0, "")}}
+			provider2 := testEnvelopeCatalogProvider{entries: []*EnvelopeCatalogEntry{NewEnvelopeCatalogEntry("1", "message 2", 0, "")}}
+
+			sut, e := NewEnvelopeCatalog([]EnvelopeCatalogProvider{provider1, provider2})
+			switch {
+			case sut != nil:
+				t.Error("returned an unexpected valid reference")
+			case e == nil:
+				t.Error("didn't returned the expected error")
+			case !errors.Is(e, ErrDuplicateEnvelopeCatalogEntry):
+				t.Errorf("(%v) when expecting (%v)", e, ErrDuplicateEnvelopeCatalogEntry)
+			}
+		})
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		t.Run("nil entry", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+
+			if e := sut.Add(nil); e == nil {
+				t.Error("didn't returned the expected error")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("duplicate entry", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(NewEnvelopeCatalogEntry(1, "message", 0, ""))
+
+			if e := sut.Add(NewEnvelopeCatalogEntry(1, "other message", 0, "")); e == nil {
+				t.Error("didn't returned the expected error")
+			} else if !errors.Is(e, ErrDuplicateEnvelopeCatalogEntry) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrDuplicateEnvelopeCatalogEntry)
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("not found", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+
+			if entry, e := sut.Get(1); entry != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrEnvelopeCatalogEntryNotFound) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrEnvelopeCatalogEntryNotFound)
+			}
+		})
+
+		t.Run("retrieve entry", func(t *testing.T) {
+			entry := NewEnvelopeCatalogEntry(1, "message", 0, "")
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(entry)
+
+			if check, e := sut.Get(1); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if check != entry {
+				t.Errorf("(%v) when expecting (%v)", check, entry)
+			}
+		})
+	})
+
+	t.Run("Entries", func(t *testing.T) {
+		t.Run("sorted numeric codes before textual codes", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(NewEnvelopeCatalogEntry("b", "", 0, ""))
+			_ = sut.Add(NewEnvelopeCatalogEntry(10, "", 0, ""))
+			_ = sut.Add(NewEnvelopeCatalogEntry("a", "", 0, ""))
+			_ = sut.Add(NewEnvelopeCatalogEntry(2, "", 0, ""))
+			expected := []string{"2", "10", "a", "b"}
+
+			var check []string
+			for _, entry := range sut.Entries() {
+				check = append(check, entry.Error)
+			}
+			if !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Run("not found", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+
+			if check, e := sut.Error(1); check != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrEnvelopeCatalogEntryNotFound) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrEnvelopeCatalogEntryNotFound)
+			}
+		})
+
+		t.Run("build error with default message", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(NewEnvelopeCatalogEntry(1, "message", 0, ""))
+			expected := NewEnvelopeStatusError(1, "message")
+
+			if check, e := sut.Error(1); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("build error with formatted message", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(NewEnvelopeCatalogEntry(1, "invalid %s", 0, ""))
+			expected := NewEnvelopeStatusError(1, "invalid name")
+
+			if check, e := sut.Error(1, "name"); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+
+	t.Run("Envelope", func(t *testing.T) {
+		t.Run("not found", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+
+			if check, e := sut.Envelope(1); check != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrEnvelopeCatalogEntryNotFound) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrEnvelopeCatalogEntryNotFound)
+			}
+		})
+
+		t.Run("build envelope with the entry status", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(NewEnvelopeCatalogEntry(1, "message", http.StatusConflict, ""))
+			expected := NewEnvelope(http.StatusConflict, nil).AddError(NewEnvelopeStatusError(1, "message"))
+
+			if check, e := sut.Envelope(1); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Run("export empty catalog", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+
+			if check, e := sut.JSON(); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if string(check) != "[]" {
+				t.Errorf("(%v) when expecting ([])", string(check))
+			}
+		})
+
+		t.Run("export entries", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(NewEnvelopeCatalogEntry(1, "message", http.StatusConflict, "description"))
+			expected := []map[string]interface{}{{
+				"error":       "1",
+				"message":     "message",
+				"status":      float64(http.StatusConflict),
+				"description": "description",
+			}}
+
+			raw, _ := sut.JSON()
+			var check []map[string]interface{}
+			_ = json.Unmarshal(raw, &check)
+			if !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		t.Run("export entries", func(t *testing.T) {
+			sut, _ := NewEnvelopeCatalog(nil)
+			_ = sut.Add(NewEnvelopeCatalogEntry(1, "a | b", http.StatusConflict, "line 1\nline 2"))
+			expected := "| c:1 | 409 | a \\| b | line 1 line 2 |"
+
+			if check := sut.Markdown(); !strings.Contains(check, expected) {
+				t.Errorf("(%v) when expecting to contain (%v)", check, expected)
+			}
+		})
+	})
+}
+
+func Test_EnvelopeCatalogServiceRegister(t *testing.T) {
+	t.Run("NewEnvelopeCatalogServiceRegister", func(t *testing.T) {
+		t.Run("create", func(t *testing.T) {
+			if NewEnvelopeCatalogServiceRegister() == nil {
+				t.Error("didn't returned a valid reference")
+			}
+		})
+
+		t.Run("create with app reference", func(t *testing.T) {
+			app := slate.NewApp()
+			if sut := NewEnvelopeCatalogServiceRegister(app); sut == nil {
+				t.Error("didn't returned a valid reference")
+			} else if sut.App != app {
+				t.Error("didn't stored the app reference")
+			}
+		})
+	})
+
+	t.Run("Provide", func(t *testing.T) {
+		t.Run("nil container", func(t *testing.T) {
+			if e := NewEnvelopeCatalogServiceRegister().Provide(nil); e == nil {
+				t.Error("didn't returned the expected error")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("register components", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			sut := NewEnvelopeCatalogServiceRegister()
+
+			e := sut.Provide(container)
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case !container.Has(EnvelopeCatalogAllProvidersContainerID):
+				t.Errorf("no catalog provider list : %v", sut)
+			case !container.Has(EnvelopeCatalogContainerID):
+				t.Errorf("no catalog : %v", sut)
+			}
+		})
+
+		t.Run("retrieving the catalog with the tagged providers", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			_ = NewEnvelopeCatalogServiceRegister().Provide(container)
+			_ = container.Add("provider", func() testEnvelopeCatalogProvider {
+				return testEnvelopeCatalogProvider{entries: []*EnvelopeCatalogEntry{NewEnvelopeCatalogEntry(1, "message", 0, "")}}
+			}, EnvelopeCatalogProviderTag)
+
+			sut, e := container.Get(EnvelopeCatalogContainerID)
+			switch {
+			case e != nil:
+				t.Errorf("unexpected error (%v)", e)
+			case sut == nil:
+				t.Error("didn't returned a reference to service")
+			default:
+				if catalog, ok := sut.(*EnvelopeCatalog); !ok {
+					t.Error("didn't returned the catalog")
+				} else if !catalog.Has(1) {
+					t.Error("didn't registered the tagged provider entries")
+				}
+			}
+		})
+	})
+
+	t.Run("Boot", func(t *testing.T) {
+		t.Run("nil container", func(t *testing.T) {
+			if e := NewEnvelopeCatalogServiceRegister().Boot(nil); e == nil {
+				t.Error("didn't returned the expected error")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("reject duplicate codes", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			sut := NewEnvelopeCatalogServiceRegister()
+			_ = sut.Provide(container)
+			_ = container.Add("provider", func() testEnvelopeCatalogProvider {
+				return testEnvelopeCatalogProvider{entries: []*EnvelopeCatalogEntry{
+					NewEnvelopeCatalogEntry(1, "message", 0, ""),
+					NewEnvelopeCatalogEntry(1, "message", 0, ""),
+				}}
+			}, EnvelopeCatalogProviderTag)
+
+			if e := sut.Boot(container); e == nil {
+				t.Error("didn't returned the expected error")
+			} else if !errors.Is(e, slate.ErrServiceContainer) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrServiceContainer)
+			}
+		})
+
+		t.Run("invalid catalog entry", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			sut := NewEnvelopeCatalogServiceRegister()
+			_ = sut.Provide(container)
+			_ = container.Add(EnvelopeCatalogContainerID, func() string { return "string" })
+
+			if e := sut.Boot(container); e == nil {
+				t.Error("didn't returned the expected error")
+			} else if !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("valid boot", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			sut := NewEnvelopeCatalogServiceRegister()
+			_ = sut.Provide(container)
+
+			if e := sut.Boot(container); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			}
+		})
+	})
+}
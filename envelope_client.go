@@ -0,0 +1,368 @@
+package sapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// EnvelopeClientEnvID defines the envelope client module base
+	// environment variable name.
+	EnvelopeClientEnvID = slate.EnvID + "_ENVELOPE_CLIENT"
+)
+
+var (
+	// EnvelopeClientListMaxPages defines the maximum number of pages
+	// retrieved by a list request of the envelope client.
+	EnvelopeClientListMaxPages = slate.EnvInt(EnvelopeClientEnvID+"_LIST_MAX_PAGES", 1000)
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrEnvelopeClientResponse defines an error that denotes that a
+	// decoded response envelope reported an unsuccessful status.
+	ErrEnvelopeClientResponse = fmt.Errorf("envelope response error")
+
+	// ErrInvalidEnvelopeResponse defines an error that denotes that a
+	// response could not be decoded as an envelope.
+	ErrInvalidEnvelopeResponse = fmt.Errorf("invalid envelope response")
+
+	// ErrEnvelopeClientPagination defines an error that denotes that the
+	// list report next links of a listing could not be followed.
+	ErrEnvelopeClientPagination = fmt.Errorf("envelope pagination error")
+)
+
+func errInvalidEnvelopeResponse(
+	msg string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrInvalidEnvelopeResponse, msg, ctx...)
+}
+
+func errEnvelopeClientPagination(
+	msg string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrEnvelopeClientPagination, msg, ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// envelope client error
+// ----------------------------------------------------------------------------
+
+// EnvelopeClientError defines the error returned by the envelope client
+// when the decoded response envelope reported an unsuccessful status.
+// The stored errors have their code decomposed in the service, endpoint,
+// param and error sections.
+type EnvelopeClientError struct {
+	StatusCode int
+	Errors     EnvelopeStatusErrorList
+}
+
+var _ error = &EnvelopeClientError{}
+
+// Error retrieve the error information from the error instance
+func (e *EnvelopeClientError) Error() string {
+	var codes []string
+	for _, err := range e.Errors {
+		codes = append(codes, fmt.Sprintf("[%s] %s", err.Code, err.Message))
+	}
+	return fmt.Sprintf("%s (%d) : %s", ErrEnvelopeClientResponse, e.StatusCode, strings.Join(codes, ", "))
+}
+
+// Unwrap will retrieve the envelope client response error
+func (e *EnvelopeClientError) Unwrap() error {
+	return ErrEnvelopeClientResponse
+}
+
+// HasError will check if any of the stored errors have the requested
+// error code section.
+func (e *EnvelopeClientError) HasError(
+	code any,
+) bool {
+	c := fmt.Sprintf("%v", code)
+	for _, err := range e.Errors {
+		if err.Error == c {
+			return true
+		}
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// envelope client
+// ----------------------------------------------------------------------------
+
+// EnvelopeClientDoer defines the interface of the instance used by the
+// envelope client to execute the http requests.
+type EnvelopeClientDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+var _ EnvelopeClientDoer = &http.Client{}
+
+// EnvelopeClient defines an instance used to execute http requests to
+// services that respond with an envelope structured format.
+type EnvelopeClient struct {
+	doer      EnvelopeClientDoer
+	baseURL   string
+	format    string
+	formatter EnvelopeCodeFormatter
+	headers   http.Header
+}
+
+// NewEnvelopeClient instantiates a new envelope client that will
+// execute the requests with the given doer to the given base URL.
+// The client will negotiate the JSON format by default.
+func NewEnvelopeClient(
+	doer EnvelopeClientDoer,
+	baseURL string,
+) (*EnvelopeClient, error) {
+	// check the doer argument reference
+	if doer == nil {
+		return nil, errNilPointer("doer")
+	}
+	return &EnvelopeClient{
+		doer:      doer,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		format:    gin.MIMEJSON,
+		formatter: nil,
+		headers:   http.Header{},
+	}, nil
+}
+
+// SetFormat assign the mime type used to negotiate the response format
+// and to encode the request body. Only the JSON and XML formats are
+// supported.
+func (c *EnvelopeClient) SetFormat(
+	format string,
+) *EnvelopeClient {
+	c.format = format
+	return c
+}
+
+// SetFormatter assign the code formatter used to decompose the
+// response error codes. A nil formatter will make the client use the
+// global formatter.
+func (c *EnvelopeClient) SetFormatter(
+	formatter EnvelopeCodeFormatter,
+) *EnvelopeClient {
+	c.formatter = formatter
+	return c
+}
+
+// SetHeader assign a header value to be sent in all requests.
+func (c *EnvelopeClient) SetHeader(
+	name,
+	value string,
+) *EnvelopeClient {
+	c.headers.Set(name, value)
+	return c
+}
+
+func (c *EnvelopeClient) request(
+	ctx context.Context,
+	method,
+	path string,
+	body interface{},
+) (*http.Response, error) {
+	// encode the request body if given
+	var reader io.Reader
+	if body != nil {
+		var raw []byte
+		var e error
+		if c.isXML(c.format) {
+			raw, e = xml.Marshal(body)
+		} else {
+			raw, e = json.Marshal(body)
+		}
+		if e != nil {
+			return nil, e
+		}
+		reader = bytes.NewReader(raw)
+	}
+	// create the request instance
+	req, e := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if e != nil {
+		return nil, e
+	}
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", c.format)
+	if body != nil {
+		req.Header.Set("Content-Type", c.format)
+	}
+	return c.doer.Do(req)
+}
+
+func (c *EnvelopeClient) decode(
+	res *http.Response,
+	target interface{},
+) error {
+	// read the response body
+	defer func() { _ = res.Body.Close() }()
+	raw, e := io.ReadAll(res.Body)
+	if e != nil {
+		return e
+	}
+	// decode the response on the response format, or on the
+	// requested format if the response does not state it
+	format := res.Header.Get("Content-Type")
+	if format == "" {
+		format = c.format
+	}
+	if c.isXML(format) {
+		e = xml.Unmarshal(raw, target)
+	} else {
+		e = json.Unmarshal(raw, target)
+	}
+	if e != nil {
+		return errInvalidEnvelopeResponse(e.Error(), map[string]interface{}{"status": res.StatusCode})
+	}
+	return nil
+}
+
+func (c *EnvelopeClient) decompose(
+	status *EnvelopeStatus,
+) {
+	formatter := c.formatter
+	if formatter == nil {
+		formatter = EnvelopeCodeFormat
+	}
+	// decompose all the response errors code sections
+	for _, err := range status.Errors {
+		if code, e := formatter.Parse(err.Code); e == nil {
+			err.Service = code.Service
+			err.Endpoint = code.Endpoint
+			err.Param = code.Param
+			err.Error = code.Error
+			err.formatter = formatter
+		}
+	}
+}
+
+func (EnvelopeClient) isXML(
+	format string,
+) bool {
+	format = strings.ToLower(format)
+	return strings.Contains(format, gin.MIMEXML) || strings.Contains(format, gin.MIMEXML2)
+}
+
+// ----------------------------------------------------------------------------
+// envelope client requests
+// ----------------------------------------------------------------------------
+
+// EnvelopeClientDo will execute a request with the given client and
+// decode the response into a typed envelope. If the response envelope
+// reports an unsuccessful status, the decoded envelope is returned
+// alongside an EnvelopeClientError instance.
+func EnvelopeClientDo[T any](
+	ctx context.Context,
+	client *EnvelopeClient,
+	method,
+	path string,
+	body interface{},
+) (*TypedEnvelope[T], error) {
+	// check the client argument reference
+	if client == nil {
+		return nil, errNilPointer("client")
+	}
+	// execute the request
+	res, e := client.request(ctx, method, path, body)
+	if e != nil {
+		return nil, e
+	}
+	// decode the response envelope
+	env := &TypedEnvelope[T]{}
+	if e := client.decode(res, env); e != nil {
+		return nil, e
+	}
+	env.StatusCode = res.StatusCode
+	if env.Status == nil {
+		return nil, errInvalidEnvelopeResponse("missing status", map[string]interface{}{"status": res.StatusCode})
+	}
+	// check the response status
+	client.decompose(env.Status)
+	if !env.Status.Success {
+		return env, &EnvelopeClientError{
+			StatusCode: res.StatusCode,
+			Errors:     env.Status.Errors,
+		}
+	}
+	return env, nil
+}
+
+// EnvelopeClientGet will execute a GET request with the given client
+// and decode the response into a typed envelope.
+func EnvelopeClientGet[T any](
+	ctx context.Context,
+	client *EnvelopeClient,
+	path string,
+) (*TypedEnvelope[T], error) {
+	return EnvelopeClientDo[T](ctx, client, http.MethodGet, path, nil)
+}
+
+// EnvelopeClientList will execute GET requests with the given client,
+// following the response list report next links, and aggregate the
+// data of all the retrieved pages. The listing fails if a next link
+// refers to an already retrieved page, or if the number of pages exceeds
+// the EnvelopeClientListMaxPages limit.
+func EnvelopeClientList[T any](
+	ctx context.Context,
+	client *EnvelopeClient,
+	path string,
+) ([]T, error) {
+	// check the context argument reference
+	if ctx == nil {
+		return nil, errNilPointer("ctx")
+	}
+	var result []T
+	// strip the query from the path to be used as the next links base
+	base := path
+	if i := strings.Index(base, "?"); i != -1 {
+		base = base[:i]
+	}
+	visited := map[string]bool{}
+	for path != "" {
+		// check the pagination guards before retrieving the page
+		if e := ctx.Err(); e != nil {
+			return nil, e
+		}
+		if visited[path] {
+			return nil, errEnvelopeClientPagination("repeated next link", map[string]interface{}{"path": path})
+		}
+		if len(visited) >= EnvelopeClientListMaxPages {
+			return nil, errEnvelopeClientPagination("too many pages", map[string]interface{}{"path": path})
+		}
+		visited[path] = true
+		// retrieve the iterated page
+		env, e := EnvelopeClientGet[[]T](ctx, client, path)
+		if e != nil {
+			return nil, e
+		}
+		result = append(result, env.Data...)
+		// follow the next page link if present
+		path = ""
+		if env.ListReport != nil && env.ListReport.Next != "" {
+			path = base + env.ListReport.Next
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,374 @@
+package sapi
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/happyhippyhippo/slate"
+)
+
+type envelopeClientTestItem struct {
+	ID int `json:"id" xml:"id,attr"`
+}
+
+func Test_envelope_client_err(t *testing.T) {
+	t.Run("errInvalidEnvelopeResponse", func(t *testing.T) {
+		arg := "dummy argument"
+		context := map[string]interface{}{"field": "value"}
+		message := "dummy argument : invalid envelope response"
+
+		if e := errInvalidEnvelopeResponse(arg, context); !errors.Is(e, ErrInvalidEnvelopeResponse) {
+			t.Errorf("error not a instance of ErrInvalidEnvelopeResponse")
+		} else if e.Error() != message {
+			t.Errorf("error message (%v) not same as expected (%v)", e, message)
+		} else if te, ok := e.(*slate.Error); !ok {
+			t.Errorf("didn't returned a slate error instance")
+		} else if check := te.Context(); !reflect.DeepEqual(check, context) {
+			t.Errorf("context (%v) not same as expected (%v)", check, context)
+		}
+	})
+}
+
+func Test_EnvelopeClientError(t *testing.T) {
+	sut := &EnvelopeClientError{
+		StatusCode: http.StatusBadRequest,
+		Errors: EnvelopeStatusErrorList{
+			&EnvelopeStatusError{Code: "s:1.c:2", Error: "2", Message: "error message"},
+		},
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		expected := "envelope response error (400) : [s:1.c:2] error message"
+
+		if check := sut.Error(); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
+	t.Run("Unwrap", func(t *testing.T) {
+		if !errors.Is(sut, ErrEnvelopeClientResponse) {
+			t.Error("error not a instance of ErrEnvelopeClientResponse")
+		}
+	})
+
+	t.Run("HasError", func(t *testing.T) {
+		if !sut.HasError(2) {
+			t.Error("didn't found the stored error code")
+		} else if sut.HasError(3) {
+			t.Error("found an unexpected error code")
+		}
+	})
+}
+
+func Test_EnvelopeClient(t *testing.T) {
+	t.Run("NewEnvelopeClient", func(t *testing.T) {
+		t.Run("nil doer", func(t *testing.T) {
+			if sut, e := NewEnvelopeClient(nil, "http://localhost"); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("construct", func(t *testing.T) {
+			if sut, e := NewEnvelopeClient(http.DefaultClient, "http://localhost/"); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if sut.baseURL != "http://localhost" {
+				t.Errorf("(%v) base url", sut.baseURL)
+			} else if sut.format != "application/json" {
+				t.Errorf("(%v) default format", sut.format)
+			}
+		})
+	})
+
+	t.Run("EnvelopeClientDo", func(t *testing.T) {
+		t.Run("nil client", func(t *testing.T) {
+			if env, e := EnvelopeClientDo[int](context.Background(), nil, http.MethodGet, "/", nil); env != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("decode json response", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Accept") != "application/json" {
+					t.Errorf("(%v) accept header", r.Header.Get("Accept"))
+				}
+				if r.Header.Get("X-Dummy") != "value" {
+					t.Errorf("(%v) custom header", r.Header.Get("X-Dummy"))
+				}
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				_ = json.NewEncoder(w).Encode(NewEnvelope(http.StatusOK, envelopeClientTestItem{ID: 12}))
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+			sut.SetHeader("X-Dummy", "value")
+
+			if env, e := EnvelopeClientGet[envelopeClientTestItem](context.Background(), sut, "/item"); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if env.StatusCode != http.StatusOK {
+				t.Errorf("(%v) status code", env.StatusCode)
+			} else if !env.Status.Success {
+				t.Error("unexpected unsuccessful status")
+			} else if env.Data.ID != 12 {
+				t.Errorf("(%v) data", env.Data)
+			}
+		})
+
+		t.Run("decode xml response", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Content-Type") != "application/xml" {
+					t.Errorf("(%v) content type header", r.Header.Get("Content-Type"))
+				}
+				w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+				w.WriteHeader(http.StatusCreated)
+				_ = xml.NewEncoder(w).Encode(NewEnvelope(http.StatusCreated, envelopeClientTestItem{ID: 12}))
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+			sut.SetFormat("application/xml")
+
+			if env, e := EnvelopeClientDo[envelopeClientTestItem](context.Background(), sut, http.MethodPost, "/item", envelopeClientTestItem{ID: 12}); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if env.StatusCode != http.StatusCreated {
+				t.Errorf("(%v) status code", env.StatusCode)
+			} else if env.Data.ID != 12 {
+				t.Errorf("(%v) data", env.Data)
+			}
+		})
+
+		t.Run("invalid response", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{"))
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+
+			if env, e := EnvelopeClientGet[int](context.Background(), sut, "/"); env != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrInvalidEnvelopeResponse) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrInvalidEnvelopeResponse)
+			}
+		})
+
+		t.Run("missing status", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+
+			if env, e := EnvelopeClientGet[int](context.Background(), sut, "/"); env != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrInvalidEnvelopeResponse) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrInvalidEnvelopeResponse)
+			}
+		})
+
+		t.Run("unsuccessful response", func(t *testing.T) {
+			for _, format := range []string{"application/json", "application/xml"} {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					env := NewEnvelope(http.StatusNotFound, nil).
+						AddError(NewEnvelopeStatusError(3, "error message").SetService(1).SetEndpoint(2).SetParam(4))
+					w.Header().Set("Content-Type", format)
+					w.WriteHeader(http.StatusNotFound)
+					if format == "application/xml" {
+						_ = xml.NewEncoder(w).Encode(env)
+					} else {
+						_ = json.NewEncoder(w).Encode(env)
+					}
+				}))
+
+				sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+				sut.SetFormat(format)
+
+				env, e := EnvelopeClientGet[int](context.Background(), sut, "/")
+				ce := &EnvelopeClientError{}
+				switch {
+				case env == nil:
+					t.Errorf("(%s) didn't returned the decoded envelope", format)
+				case !errors.As(e, &ce):
+					t.Errorf("(%s) (%v) when expecting a client error", format, e)
+				case ce.StatusCode != http.StatusNotFound:
+					t.Errorf("(%s) (%v) status code", format, ce.StatusCode)
+				case len(ce.Errors) != 1:
+					t.Errorf("(%s) (%d) errors when expecting 1", format, len(ce.Errors))
+				default:
+					err := ce.Errors[0]
+					if err.Service != 1 || err.Endpoint != 2 || err.Param != 4 || err.Error != "3" {
+						t.Errorf("(%s) (%v) invalid decomposed error code", format, err)
+					}
+				}
+				server.Close()
+			}
+		})
+
+		t.Run("decompose with the assigned formatter", func(t *testing.T) {
+			formatter := NewEnvelopePaddedCodeFormatter("-")
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				env := NewEnvelope(http.StatusConflict, nil).
+					AddError(NewEnvelopeStatusError(3, "error message").SetService(1).SetEndpoint(2))
+				env.SetFormatter(formatter)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(env)
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+			sut.SetFormatter(formatter)
+
+			_, e := EnvelopeClientGet[int](context.Background(), sut, "/")
+			ce := &EnvelopeClientError{}
+			if !errors.As(e, &ce) {
+				t.Errorf("(%v) when expecting a client error", e)
+			} else if err := ce.Errors[0]; err.Service != 1 || err.Endpoint != 2 || err.Error != "3" {
+				t.Errorf("(%v) invalid decomposed error code", err)
+			}
+		})
+	})
+
+	t.Run("EnvelopeClientList", func(t *testing.T) {
+		t.Run("follow next links", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/items" {
+					t.Errorf("(%v) unexpected path", r.URL.Path)
+				}
+				start := 0
+				_, _ = fmt.Sscanf(r.URL.Query().Get("start"), "%d", &start)
+				env := NewEnvelope(http.StatusOK, []envelopeClientTestItem{{ID: start}, {ID: start + 1}}).
+					SetListReport(NewEnvelopeListReport("", uint(start), 2, 6))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(env)
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+			expected := []envelopeClientTestItem{{ID: 0}, {ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+
+			if check, e := EnvelopeClientList[envelopeClientTestItem](context.Background(), sut, "/items?start=0&count=2"); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("nil context", func(t *testing.T) {
+			sut, _ := NewEnvelopeClient(&http.Client{}, "http://localhost")
+
+			//nolint:staticcheck
+			if check, e := EnvelopeClientList[int](nil, sut, "/items"); check != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("repeated next link", func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls++
+				env := NewEnvelope(http.StatusOK, []envelopeClientTestItem{{ID: calls}}).
+					SetListReport(NewEnvelopeListReport("", 0, 1, 10))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(env)
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+
+			if check, e := EnvelopeClientList[envelopeClientTestItem](context.Background(), sut, "/items?start=0&count=1"); check != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrEnvelopeClientPagination) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrEnvelopeClientPagination)
+			} else if calls != 2 {
+				t.Errorf("(%v) when expecting (2) requests", calls)
+			}
+		})
+
+		t.Run("too many pages", func(t *testing.T) {
+			prev := EnvelopeClientListMaxPages
+			EnvelopeClientListMaxPages = 2
+			defer func() { EnvelopeClientListMaxPages = prev }()
+
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				start := 0
+				_, _ = fmt.Sscanf(r.URL.Query().Get("start"), "%d", &start)
+				env := NewEnvelope(http.StatusOK, []envelopeClientTestItem{{ID: start}}).
+					SetListReport(NewEnvelopeListReport("", uint(start), 1, 10))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(env)
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+
+			if check, e := EnvelopeClientList[envelopeClientTestItem](context.Background(), sut, "/items?start=0&count=1"); check != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrEnvelopeClientPagination) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrEnvelopeClientPagination)
+			} else if calls != 2 {
+				t.Errorf("(%v) when expecting (2) requests", calls)
+			}
+		})
+
+		t.Run("cancelled context between pages", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				cancel()
+				start := 0
+				_, _ = fmt.Sscanf(r.URL.Query().Get("start"), "%d", &start)
+				env := NewEnvelope(http.StatusOK, []envelopeClientTestItem{{ID: start}}).
+					SetListReport(NewEnvelopeListReport("", uint(start), 1, 10))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(env)
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+
+			if check, e := EnvelopeClientList[envelopeClientTestItem](ctx, sut, "/items?start=0&count=1"); check != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, context.Canceled) {
+				t.Errorf("(%v) when expecting (%v)", e, context.Canceled)
+			} else if calls != 1 {
+				t.Errorf("(%v) when expecting (1) request", calls)
+			}
+		})
+
+		t.Run("page error", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(NewEnvelope(http.StatusInternalServerError, nil).AddError(NewEnvelopeStatusError(1, "error")))
+			}))
+			defer server.Close()
+
+			sut, _ := NewEnvelopeClient(server.Client(), server.URL)
+
+			if check, e := EnvelopeClientList[int](context.Background(), sut, "/items"); check != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrEnvelopeClientResponse) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrEnvelopeClientResponse)
+			}
+		})
+	})
+}
@@ -1,13 +1,204 @@
 package sapi
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/happyhippyhippo/slate"
 )
 
+func Test_envelope_err(t *testing.T) {
+	t.Run("errInvalidEnvelopeCode", func(t *testing.T) {
+		arg := "dummy argument"
+		message := "dummy argument : invalid envelope error code"
+
+		if e := errInvalidEnvelopeCode(arg); !errors.Is(e, ErrInvalidEnvelopeCode) {
+			t.Errorf("error not a instance of ErrInvalidEnvelopeCode")
+		} else if e.Error() != message {
+			t.Errorf("error message (%v) not same as expected (%v)", e, message)
+		}
+	})
+
+	t.Run("errUnknownEnvelopeCodeFormat", func(t *testing.T) {
+		arg := "dummy argument"
+		message := "dummy argument : unknown envelope error code format"
+
+		if e := errUnknownEnvelopeCodeFormat(arg); !errors.Is(e, ErrUnknownEnvelopeCodeFormat) {
+			t.Errorf("error not a instance of ErrUnknownEnvelopeCodeFormat")
+		} else if e.Error() != message {
+			t.Errorf("error message (%v) not same as expected (%v)", e, message)
+		}
+	})
+}
+
+func Test_EnvelopeCodeFormatter(t *testing.T) {
+	t.Run("NewEnvelopeCodeFormatter", func(t *testing.T) {
+		t.Run("unknown format", func(t *testing.T) {
+			if sut, e := NewEnvelopeCodeFormatter("unknown", ""); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, ErrUnknownEnvelopeCodeFormat) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrUnknownEnvelopeCodeFormat)
+			}
+		})
+
+		t.Run("default to the std format", func(t *testing.T) {
+			sut, e := NewEnvelopeCodeFormatter("", "")
+			if e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if _, ok := sut.(*EnvelopeStdCodeFormatter); !ok {
+				t.Errorf("(%T) when expecting std formatter", sut)
+			}
+		})
+
+		t.Run("padded format", func(t *testing.T) {
+			expected := &EnvelopePaddedCodeFormatter{Separator: "_", ServiceWidth: 2, EndpointWidth: 3, ParamWidth: 2, ErrorWidth: 3}
+
+			if sut, e := NewEnvelopeCodeFormatter("PADDED", "_", 2); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(sut, expected) {
+				t.Errorf("(%v) when expecting (%v)", sut, expected)
+			}
+		})
+	})
+
+	t.Run("ParseEnvelopeCode", func(t *testing.T) {
+		t.Run("parse with the global formatter", func(t *testing.T) {
+			prev := EnvelopeCodeFormat
+			EnvelopeCodeFormat = NewEnvelopePaddedCodeFormatter("-")
+			defer func() { EnvelopeCodeFormat = prev }()
+
+			expected := &EnvelopeCode{Service: 1, Endpoint: 2, Param: 3, Error: "4"}
+
+			if check, e := ParseEnvelopeCode("001-002-03-004"); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+}
+
+func Test_EnvelopeStdCodeFormatter(t *testing.T) {
+	scenarios := []struct {
+		code EnvelopeCode
+		str  string
+	}{
+		{ // empty code
+			code: EnvelopeCode{},
+			str:  "",
+		},
+		{ // only error
+			code: EnvelopeCode{Error: "123"},
+			str:  "c:123",
+		},
+		{ // all sections
+			code: EnvelopeCode{Service: 1, Endpoint: 2, Param: 3, Error: "4"},
+			str:  "s:1.e:2.p:3.c:4",
+		},
+		{ // textual error
+			code: EnvelopeCode{Service: 1, Param: 3, Error: "error.code"},
+			str:  "s:1.p:3.error.code",
+		},
+	}
+
+	t.Run("Format", func(t *testing.T) {
+		for _, scenario := range scenarios {
+			if check := NewEnvelopeStdCodeFormatter().Format(scenario.code); check != scenario.str {
+				t.Errorf("(%v) when expecting (%v)", check, scenario.str)
+			}
+		}
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		for _, scenario := range scenarios {
+			if check, e := NewEnvelopeStdCodeFormatter().Parse(scenario.str); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(*check, scenario.code) {
+				t.Errorf("(%v) when expecting (%v)", *check, scenario.code)
+			}
+		}
+
+		t.Run("invalid code section position", func(t *testing.T) {
+			if _, e := NewEnvelopeStdCodeFormatter().Parse("c:1.s:2"); !errors.Is(e, ErrInvalidEnvelopeCode) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrInvalidEnvelopeCode)
+			}
+		})
+	})
+}
+
+func Test_EnvelopePaddedCodeFormatter(t *testing.T) {
+	t.Run("NewEnvelopePaddedCodeFormatter", func(t *testing.T) {
+		t.Run("default values", func(t *testing.T) {
+			expected := &EnvelopePaddedCodeFormatter{Separator: "-", ServiceWidth: 3, EndpointWidth: 3, ParamWidth: 2, ErrorWidth: 3}
+
+			if check := NewEnvelopePaddedCodeFormatter(""); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("given values", func(t *testing.T) {
+			expected := &EnvelopePaddedCodeFormatter{Separator: ".", ServiceWidth: 1, EndpointWidth: 2, ParamWidth: 3, ErrorWidth: 4}
+
+			if check := NewEnvelopePaddedCodeFormatter(".", 1, 2, 3, 4); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+
+	scenarios := []struct {
+		code EnvelopeCode
+		str  string
+	}{
+		{ // empty code
+			code: EnvelopeCode{Error: ""},
+			str:  "000-000-00-",
+		},
+		{ // all sections
+			code: EnvelopeCode{Service: 1, Endpoint: 2, Param: 3, Error: "104"},
+			str:  "001-002-03-0104",
+		},
+		{ // textual error
+			code: EnvelopeCode{Service: 1, Endpoint: 2, Param: 3, Error: "error-code"},
+			str:  "001-002-03-error-code",
+		},
+	}
+
+	t.Run("Format", func(t *testing.T) {
+		for _, scenario := range scenarios {
+			if check := NewEnvelopePaddedCodeFormatter("-", 3, 3, 2, 4).Format(scenario.code); check != scenario.str {
+				t.Errorf("(%v) when expecting (%v)", check, scenario.str)
+			}
+		}
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		for _, scenario := range scenarios {
+			if check, e := NewEnvelopePaddedCodeFormatter("-", 3, 3, 2, 4).Parse(scenario.str); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !reflect.DeepEqual(*check, scenario.code) {
+				t.Errorf("(%v) when expecting (%v)", *check, scenario.code)
+			}
+		}
+
+		t.Run("missing sections", func(t *testing.T) {
+			if _, e := NewEnvelopePaddedCodeFormatter("-").Parse("001-002"); !errors.Is(e, ErrInvalidEnvelopeCode) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrInvalidEnvelopeCode)
+			}
+		})
+
+		t.Run("non numeric section", func(t *testing.T) {
+			if _, e := NewEnvelopePaddedCodeFormatter("-").Parse("001-abc-01-001"); !errors.Is(e, ErrInvalidEnvelopeCode) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrInvalidEnvelopeCode)
+			}
+		})
+	})
+}
+
 func Test_EnvelopeStatusError(t *testing.T) {
 	t.Run("NewEnvelopeStatusError", func(t *testing.T) {
 		t.Run("construct", func(t *testing.T) {
@@ -145,6 +336,25 @@ func Test_EnvelopeStatusError(t *testing.T) {
 		})
 	})
 
+	t.Run("SetFormatter", func(t *testing.T) {
+		t.Run("assign", func(t *testing.T) {
+			formatter := NewEnvelopePaddedCodeFormatter("-")
+			e := NewEnvelopeStatusError(4, "message").SetService(1).SetFormatter(formatter)
+
+			if check := e.Code; check != "001-000-00-004" {
+				t.Errorf("(%v) when expecting (001-000-00-004)", check)
+			}
+		})
+
+		t.Run("nil formatter uses the global formatter", func(t *testing.T) {
+			e := NewEnvelopeStatusError(4, "message").SetService(1).SetFormatter(nil)
+
+			if check := e.Code; check != "s:1.c:4" {
+				t.Errorf("(%v) when expecting (s:1.c:4)", check)
+			}
+		})
+	})
+
 	t.Run("GetCode", func(t *testing.T) {
 		t.Run("retrieval", func(t *testing.T) {
 			service := 12
@@ -165,6 +375,26 @@ func Test_EnvelopeStatusError(t *testing.T) {
 		})
 	})
 
+	t.Run("SetField", func(t *testing.T) {
+		t.Run("assign the field name", func(t *testing.T) {
+			e := NewEnvelopeStatusError(1, "message").SetField("qty")
+
+			if check := e.GetField(); check != "qty" {
+				t.Errorf("(%v) when expecting (qty)", check)
+			}
+		})
+	})
+
+	t.Run("SetPath", func(t *testing.T) {
+		t.Run("assign the field path", func(t *testing.T) {
+			e := NewEnvelopeStatusError(1, "message").SetPath("items[1].qty")
+
+			if check := e.GetPath(); check != "items[1].qty" {
+				t.Errorf("(%v) when expecting (items[1].qty)", check)
+			}
+		})
+	})
+
 	t.Run("GetMessage", func(t *testing.T) {
 		t.Run("retrieval", func(t *testing.T) {
 			msg := "message"
@@ -305,6 +535,69 @@ func Test_EnvelopeStatusErrorList(t *testing.T) {
 				t.Errorf("(%v) when expecting (%v)", check, expected)
 			}
 		})
+
+		t.Run("element with field path", func(t *testing.T) {
+			name := "start"
+			buffer := strings.Builder{}
+			start := xml.StartElement{Name: xml.Name{Local: name}}
+			list := EnvelopeStatusErrorList{
+				NewEnvelopeStatusError(1, "error message").SetField("qty").SetPath("items[1].qty"),
+			}
+			expected := `<start><error code="c:1" message="error message" field="qty" path="items[1].qty"></error></start>`
+
+			if err := list.MarshalXML(xml.NewEncoder(&buffer), start); err != nil {
+				t.Errorf("returned the ujnexpected error (%v)", err)
+			} else if check := buffer.String(); check != expected {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+
+	t.Run("UnmarshalXML", func(t *testing.T) {
+		t.Run("empty list", func(t *testing.T) {
+			list := EnvelopeStatusErrorList{}
+
+			if err := xml.Unmarshal([]byte(`<start></start>`), &list); err != nil {
+				t.Errorf("returned the unexpected error (%v)", err)
+			} else if len(list) != 0 {
+				t.Errorf("(%v) when expecting empty list", list)
+			}
+		})
+
+		t.Run("multiple element list", func(t *testing.T) {
+			data := `<start>`
+			data += `<error code="s:2.e:3.c:1" message="error message 1"></error>`
+			data += `<error code="s:2.e:3.c:2" message="error message 2" field="name" path="field"><ignored/></error>`
+			data += `</start>`
+			list := EnvelopeStatusErrorList{}
+
+			if err := xml.Unmarshal([]byte(data), &list); err != nil {
+				t.Errorf("returned the unexpected error (%v)", err)
+			} else if len(list) != 2 {
+				t.Errorf("(%d) elements when expecting 2", len(list))
+			} else if list[0].Code != "s:2.e:3.c:1" || list[0].Message != "error message 1" {
+				t.Errorf("(%v) unexpected first element", list[0])
+			} else if list[1].Code != "s:2.e:3.c:2" || list[1].Message != "error message 2" || list[1].Field != "name" || list[1].Path != "field" {
+				t.Errorf("(%v) unexpected second element", list[1])
+			}
+		})
+
+		t.Run("marshal round trip", func(t *testing.T) {
+			env := NewEnvelope(400, nil).
+				AddError(NewEnvelopeStatusError(1, "error message").SetService(2).SetEndpoint(3))
+			raw, _ := xml.Marshal(env)
+			check := Envelope{}
+
+			if err := xml.Unmarshal(raw, &check); err != nil {
+				t.Errorf("returned the unexpected error (%v)", err)
+			} else if check.Status.Success {
+				t.Error("unexpected successful status")
+			} else if len(check.Status.Errors) != 1 {
+				t.Errorf("(%d) errors when expecting 1", len(check.Status.Errors))
+			} else if code := check.Status.Errors[0].Code; code != "s:2.e:3.c:1" {
+				t.Errorf("(%v) when expecting (s:2.e:3.c:1)", code)
+			}
+		})
 	})
 }
 
@@ -665,4 +958,166 @@ func Test_Envelope(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("SetMeta", func(t *testing.T) {
+		t.Run("assign the meta information", func(t *testing.T) {
+			meta := NewEnvelopeMeta()
+			env := NewEnvelope(200, nil).SetMeta(meta)
+
+			if env.Meta != meta {
+				t.Errorf("(%v) when expecting (%v)", env.Meta, meta)
+			}
+		})
+	})
+
+	t.Run("AddWarning", func(t *testing.T) {
+		t.Run("add warning without flagging the status", func(t *testing.T) {
+			w := NewEnvelopeMetaWarning(1, "warning message")
+			env := NewEnvelope(200, nil).AddWarning(w)
+
+			if check := env.Status.Success; check != true {
+				t.Error("flagged the status as unsuccessful")
+			} else if env.Meta == nil {
+				t.Error("didn't created the meta information")
+			} else if !reflect.DeepEqual(env.Meta.Warnings, []*EnvelopeMetaWarning{w}) {
+				t.Errorf("(%v) when expecting (%v)", env.Meta.Warnings, []*EnvelopeMetaWarning{w})
+			}
+		})
+	})
+
+	t.Run("marshal meta information", func(t *testing.T) {
+		env := NewEnvelope(200, nil).SetMeta(&EnvelopeMeta{
+			RequestID:  "id",
+			ServerTime: "time",
+			Duration:   1.5,
+			Version:    "v1",
+		}).AddWarning(NewEnvelopeMetaWarning(1, "warning message"))
+
+		t.Run("json", func(t *testing.T) {
+			expected := `{"status":{"success":true,"error":[]},"meta":{"requestId":"id","serverTime":"time","duration":1.5,"version":"v1","warnings":[{"code":"1","message":"warning message"}]}}`
+
+			if check, e := json.Marshal(env); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if string(check) != expected {
+				t.Errorf("(%v) when expecting (%v)", string(check), expected)
+			}
+		})
+
+		t.Run("xml", func(t *testing.T) {
+			expected := `<envelope><status><success>true</success><error></error></status><meta><requestId>id</requestId><serverTime>time</serverTime><duration>1.5</duration><version>v1</version><warnings><warning code="1" message="warning message"></warning></warnings></meta></envelope>`
+
+			if check, e := xml.Marshal(env); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if string(check) != expected {
+				t.Errorf("(%v) when expecting (%v)", string(check), expected)
+			}
+		})
+	})
+}
+
+func Test_TypedEnvelope(t *testing.T) {
+	t.Run("NewTypedEnvelope", func(t *testing.T) {
+		t.Run("construct without list report", func(t *testing.T) {
+			sut := NewTypedEnvelope(201, 123)
+
+			if check := sut.GetStatusCode(); check != 201 {
+				t.Errorf("(%v) status code when expecting (201)", check)
+			} else if !sut.Status.Success {
+				t.Error("unexpected unsuccessful status")
+			} else if sut.ListReport != nil {
+				t.Error("unexpected list report")
+			} else if sut.Data != 123 {
+				t.Errorf("(%v) data when expecting (123)", sut.Data)
+			}
+		})
+
+		t.Run("construct with list report", func(t *testing.T) {
+			report := NewEnvelopeListReport("", 0, 2, 4)
+			sut := NewTypedListEnvelope(200, []string{"data1", "data2"}, report)
+
+			if sut.ListReport != report {
+				t.Error("didn't stored the list report")
+			} else if !reflect.DeepEqual(sut.Data, []string{"data1", "data2"}) {
+				t.Errorf("(%v) unexpected data", sut.Data)
+			}
+		})
+	})
+
+	t.Run("TypedEnvelopeFrom", func(t *testing.T) {
+		t.Run("nil envelope", func(t *testing.T) {
+			if sut, e := TypedEnvelopeFrom[int](nil); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("invalid data type", func(t *testing.T) {
+			if sut, e := TypedEnvelopeFrom[int](NewEnvelope(200, "string")); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("nil data", func(t *testing.T) {
+			if sut, e := TypedEnvelopeFrom[[]int](NewEnvelope(200, nil)); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if sut.Data != nil {
+				t.Errorf("(%v) when expecting nil data", sut.Data)
+			}
+		})
+
+		t.Run("convert", func(t *testing.T) {
+			env := NewEnvelope(200, 123).SetMeta(NewEnvelopeMeta())
+
+			if sut, e := TypedEnvelopeFrom[int](env); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if sut.Data != 123 || sut.StatusCode != 200 || sut.Status != env.Status || sut.Meta != env.Meta {
+				t.Errorf("(%v) invalid converted envelope", sut)
+			}
+		})
+	})
+
+	t.Run("Envelope", func(t *testing.T) {
+		sut := NewTypedEnvelope(200, 123).AddWarning(NewEnvelopeMetaWarning(1, "warning"))
+		env := sut.Envelope()
+
+		if env.StatusCode != 200 || env.Status != sut.Status || env.Meta != sut.Meta || env.Data != 123 {
+			t.Errorf("(%v) invalid converted envelope", env)
+		}
+	})
+
+	t.Run("setters", func(t *testing.T) {
+		report := NewEnvelopeListReport("", 0, 1, 1)
+		meta := NewEnvelopeMeta()
+		sut := NewTypedEnvelope(400, 123).
+			AddError(NewEnvelopeStatusError(1, "error")).
+			SetFormatter(NewEnvelopePaddedCodeFormatter("-")).
+			SetService(2).
+			SetEndpoint(3).
+			SetListReport(report).
+			SetMeta(meta)
+
+		if sut.Status.Success {
+			t.Error("unexpected successful status")
+		} else if code := sut.Status.Errors[0].GetCode(); code != "002-003-00-001" {
+			t.Errorf("(%v) when expecting (002-003-00-001)", code)
+		} else if sut.ListReport != report {
+			t.Error("didn't stored the list report")
+		} else if sut.Meta != meta {
+			t.Error("didn't stored the meta information")
+		}
+	})
+
+	t.Run("json marshal", func(t *testing.T) {
+		sut := NewTypedEnvelope(200, []int{1, 2})
+		expected := `{"status":{"success":true,"error":[]},"data":[1,2]}`
+
+		if check, e := json.Marshal(sut); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if string(check) != expected {
+			t.Errorf("(%v) when expecting (%v)", string(check), expected)
+		}
+	})
 }
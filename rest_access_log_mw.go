@@ -0,0 +1,469 @@
+package sapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// RestAccessLogMwContainerID defines the default id used to register
+	// the application access log middleware and related services.
+	RestAccessLogMwContainerID = RestContainerID + ".access.log.mw"
+
+	// RestAccessLogMwEnvID defines the access log middleware module base
+	// environment variable name.
+	RestAccessLogMwEnvID = RestEnvID + "_ACCESS_LOG_MW"
+
+	// RestAccessLogFormatCommon defines the name of the NCSA common log
+	// format.
+	RestAccessLogFormatCommon = "common"
+
+	// RestAccessLogFormatCombined defines the name of the NCSA combined
+	// log format.
+	RestAccessLogFormatCombined = "combined"
+
+	// RestAccessLogFormatW3C defines the name of the W3C extended log
+	// format.
+	RestAccessLogFormatW3C = "w3c"
+)
+
+var (
+	// RestAccessLogMwConfigPathFormat defines the config path used to store
+	// the access log format name or custom template.
+	RestAccessLogMwConfigPathFormat = slate.EnvString(RestAccessLogMwEnvID+"_CONFIG_PATH_FORMAT", "slate.api.rest.log.access.format")
+
+	// RestAccessLogMwFormat defines the default access log format used if
+	// no format is defined in the configuration.
+	RestAccessLogMwFormat = slate.EnvString(RestAccessLogMwEnvID+"_FORMAT", RestAccessLogFormatCombined)
+
+	// RestAccessLogMwChannel defines the channel id to be used when the
+	// access log middleware sends the access log line to the logger instance.
+	RestAccessLogMwChannel = slate.EnvString(RestAccessLogMwEnvID+"_CHANNEL", "access")
+
+	// RestAccessLogMwLevel defines the logging level to be used when the
+	// access log middleware sends the access log line to the logger instance.
+	RestAccessLogMwLevel = envToLogLevel(RestAccessLogMwEnvID+"_LEVEL", slate.INFO)
+
+	// RestAccessLogMwLogFormatErrorMessage defines the logging message used
+	// when the access log format configuration is invalid.
+	RestAccessLogMwLogFormatErrorMessage = slate.EnvString(RestAccessLogMwEnvID+"_LOG_FORMAT_ERROR_MESSAGE", "Invalid access log format")
+)
+
+// ----------------------------------------------------------------------------
+// Rest Access Log Formatter
+// ----------------------------------------------------------------------------
+
+// RestAccessLogEntry defines the information of a handled request used
+// to compose an access log line. If a redactor is given, the query params
+// and request headers are redacted with the log middleware rules.
+type RestAccessLogEntry struct {
+	Context  *gin.Context
+	Time     time.Time
+	Duration time.Duration
+	Redactor *RestLogMwRedactor
+}
+
+func (e *RestAccessLogEntry) query() string {
+	query := e.Context.Request.URL.RawQuery
+	if e.Redactor != nil && query != "" {
+		query = e.Redactor.RedactQuery(query)
+	}
+	return query
+}
+
+func (e *RestAccessLogEntry) uri() string {
+	uri := *e.Context.Request.URL
+	uri.RawQuery = e.query()
+	return uri.RequestURI()
+}
+
+func (e *RestAccessLogEntry) header(
+	name string,
+) string {
+	value := e.Context.Request.Header.Get(name)
+	if e.Redactor != nil && value != "" {
+		value = e.Redactor.RedactHeader(name, value)
+	}
+	return value
+}
+
+// RestAccessLogFormatter defines the function used to compose an access
+// log line from a handled request information.
+type RestAccessLogFormatter func(entry *RestAccessLogEntry) string
+
+// NewRestAccessLogFormatter will instantiate a new access log formatter.
+// The format can be one of the common, combined or w3c predefined formats,
+// or a custom template using the Apache log format directives :
+//
+//	%h remote address       %l remote logname (always -)
+//	%u remote user          %t request time
+//	%r request line         %s or %>s response status
+//	%b response size (CLF)  %B response size
+//	%D duration in µs       %T duration in seconds
+//	%m request method       %U request path
+//	%q query string         %H request protocol
+//	%{name}i request header %{name}o response header
+//	%% percent sign
+func NewRestAccessLogFormatter(
+	format string,
+) (RestAccessLogFormatter, error) {
+	switch strings.ToLower(format) {
+	case RestAccessLogFormatCommon:
+		format = `%h %l %u %t "%r" %>s %b`
+	case RestAccessLogFormatCombined:
+		format = `%h %l %u %t "%r" %>s %b "%{Referer}i" "%{User-Agent}i"`
+	case RestAccessLogFormatW3C:
+		return restAccessLogW3C, nil
+	}
+	// parse the template directives
+	directives, e := restAccessLogParse(format)
+	if e != nil {
+		return nil, e
+	}
+	return func(entry *RestAccessLogEntry) string {
+		b := strings.Builder{}
+		for _, d := range directives {
+			b.WriteString(d(entry))
+		}
+		return b.String()
+	}, nil
+}
+
+type restAccessLogDirective func(entry *RestAccessLogEntry) string
+
+func restAccessLogParse(
+	format string,
+) ([]restAccessLogDirective, error) {
+	var directives []restAccessLogDirective
+	literal := strings.Builder{}
+	flush := func() {
+		if literal.Len() != 0 {
+			text := literal.String()
+			directives = append(directives, func(*RestAccessLogEntry) string { return text })
+			literal.Reset()
+		}
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			literal.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i >= len(format) {
+			return nil, errConversion(format, "access log format")
+		}
+		// parse the directive argument if present
+		arg := ""
+		if format[i] == '{' {
+			end := strings.IndexByte(format[i:], '}')
+			if end == -1 {
+				return nil, errConversion(format, "access log format")
+			}
+			arg = format[i+1 : i+end]
+			i += end + 1
+			if i >= len(format) {
+				return nil, errConversion(format, "access log format")
+			}
+		}
+		// ignore the final status modifier
+		if format[i] == '>' && i+1 < len(format) {
+			i++
+		}
+		d := restAccessLogDirectiveOf(format[i], arg)
+		if d == nil {
+			if format[i] == '%' {
+				literal.WriteByte('%')
+				continue
+			}
+			return nil, errConversion(format, "access log format")
+		}
+		flush()
+		directives = append(directives, d)
+	}
+	flush()
+	return directives, nil
+}
+
+func restAccessLogDirectiveOf(
+	directive byte,
+	arg string,
+) restAccessLogDirective {
+	switch directive {
+	case 'h':
+		return func(e *RestAccessLogEntry) string { return restAccessLogValue(e.Context.ClientIP()) }
+	case 'l':
+		return func(*RestAccessLogEntry) string { return "-" }
+	case 'u':
+		return func(e *RestAccessLogEntry) string { return restAccessLogValue(restAccessLogUser(e.Context)) }
+	case 't':
+		return func(e *RestAccessLogEntry) string { return e.Time.Format("[02/Jan/2006:15:04:05 -0700]") }
+	case 'r':
+		return func(e *RestAccessLogEntry) string {
+			return restAccessLogValue(fmt.Sprintf("%s %s %s", e.Context.Request.Method, e.uri(), e.Context.Request.Proto))
+		}
+	case 's':
+		return func(e *RestAccessLogEntry) string { return strconv.Itoa(e.Context.Writer.Status()) }
+	case 'b':
+		return func(e *RestAccessLogEntry) string {
+			if size := e.Context.Writer.Size(); size > 0 {
+				return strconv.Itoa(size)
+			}
+			return "-"
+		}
+	case 'B':
+		return func(e *RestAccessLogEntry) string { return strconv.Itoa(restAccessLogSize(e.Context)) }
+	case 'D':
+		return func(e *RestAccessLogEntry) string { return strconv.FormatInt(e.Duration.Microseconds(), 10) }
+	case 'T':
+		return func(e *RestAccessLogEntry) string { return strconv.FormatInt(int64(e.Duration.Seconds()), 10) }
+	case 'm':
+		return func(e *RestAccessLogEntry) string { return e.Context.Request.Method }
+	case 'U':
+		return func(e *RestAccessLogEntry) string { return restAccessLogValue(e.Context.Request.URL.Path) }
+	case 'q':
+		return func(e *RestAccessLogEntry) string {
+			if q := e.query(); q != "" {
+				return restAccessLogValue("?" + q)
+			}
+			return ""
+		}
+	case 'H':
+		return func(e *RestAccessLogEntry) string { return e.Context.Request.Proto }
+	case 'i':
+		return func(e *RestAccessLogEntry) string { return restAccessLogValue(e.header(arg)) }
+	case 'o':
+		return func(e *RestAccessLogEntry) string { return restAccessLogValue(e.Context.Writer.Header().Get(arg)) }
+	}
+	return nil
+}
+
+const restAccessLogW3CFields = "date time c-ip cs-username cs-method cs-uri-stem cs-uri-query sc-status sc-bytes time-taken cs(User-Agent) cs(Referer)"
+
+// restAccessLogHeader retrieves the directive lines that must precede the
+// access log lines of the given format, as the W3C extended log format
+// #Version and #Fields directives.
+func restAccessLogHeader(
+	format string,
+	t time.Time,
+) []string {
+	if strings.ToLower(format) != RestAccessLogFormatW3C {
+		return nil
+	}
+	return []string{
+		"#Version: 1.0",
+		"#Date: " + t.UTC().Format("2006-01-02 15:04:05"),
+		"#Fields: " + restAccessLogW3CFields,
+	}
+}
+
+func restAccessLogW3C(
+	entry *RestAccessLogEntry,
+) string {
+	ctx := entry.Context
+	t := entry.Time.UTC()
+	fields := []string{
+		t.Format("2006-01-02"),
+		t.Format("15:04:05"),
+		ctx.ClientIP(),
+		restAccessLogUser(ctx),
+		ctx.Request.Method,
+		ctx.Request.URL.Path,
+		entry.query(),
+		strconv.Itoa(ctx.Writer.Status()),
+		strconv.Itoa(restAccessLogSize(ctx)),
+		strconv.FormatInt(entry.Duration.Milliseconds(), 10),
+		entry.header("User-Agent"),
+		entry.header("Referer"),
+	}
+	for i, field := range fields {
+		fields[i] = strings.ReplaceAll(restAccessLogValue(field), " ", "+")
+	}
+	return strings.Join(fields, " ")
+}
+
+func restAccessLogUser(
+	ctx *gin.Context,
+) string {
+	if user, _, ok := ctx.Request.BasicAuth(); ok {
+		return user
+	}
+	return ""
+}
+
+func restAccessLogSize(
+	ctx *gin.Context,
+) int {
+	if size := ctx.Writer.Size(); size > 0 {
+		return size
+	}
+	return 0
+}
+
+func restAccessLogValue(
+	value string,
+) string {
+	if value == "" {
+		return "-"
+	}
+	// escape the backslashes, quotes and control characters, so the
+	// value can't close a quoted field or break the log line
+	b := strings.Builder{}
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\' || c == '"':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			_, _ = fmt.Fprintf(&b, "\\x%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// ----------------------------------------------------------------------------
+// Rest Access Log Middleware Generator
+// ----------------------------------------------------------------------------
+
+// RestAccessLogMwGenerator defines the function used to generate the
+// access log middleware.
+type RestAccessLogMwGenerator func() RestMiddleware
+
+// NewRestAccessLogMwGenerator instantiates a new access log middleware
+// generator. The generated middlewares will send a single access log line
+// per request, formatted with the configured format, to the access log
+// channel. The W3C format directives are sent once, before the first line
+// of the format. The query params and request headers are redacted with
+// the given log middleware redactor.
+func NewRestAccessLogMwGenerator(
+	config *slate.Config,
+	logger *slate.Log,
+	redactor *RestLogMwRedactor,
+) (RestAccessLogMwGenerator, error) {
+	// check config argument reference
+	if config == nil {
+		return nil, errNilPointer("config")
+	}
+	// check logger argument reference
+	if logger == nil {
+		return nil, errNilPointer("logger")
+	}
+	// check redactor argument reference
+	if redactor == nil {
+		return nil, errNilPointer("redactor")
+	}
+	// retrieve the access log format from the configuration
+	format, e := config.String(RestAccessLogMwConfigPathFormat, RestAccessLogMwFormat)
+	if e != nil {
+		_ = logger.Signal(RestAccessLogMwChannel, slate.ERROR, RestAccessLogMwLogFormatErrorMessage, slate.LogContext{"error": e})
+		return nil, e
+	}
+	formatter, e := NewRestAccessLogFormatter(format)
+	if e != nil {
+		_ = logger.Signal(RestAccessLogMwChannel, slate.ERROR, RestAccessLogMwLogFormatErrorMessage, slate.LogContext{"error": e})
+		return nil, e
+	}
+	// add a config observer for the access log format, flagging the
+	// format directives header to be sent before the next access log line
+	mutex := &sync.Mutex{}
+	header := format
+	if config.Has(RestAccessLogMwConfigPathFormat) {
+		_ = config.AddObserver(RestAccessLogMwConfigPathFormat, func(_ interface{}, new interface{}) {
+			format, ok := new.(string)
+			if !ok {
+				_ = logger.Signal(RestAccessLogMwChannel, slate.ERROR, RestAccessLogMwLogFormatErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			updated, e := NewRestAccessLogFormatter(format)
+			if e != nil {
+				_ = logger.Signal(RestAccessLogMwChannel, slate.ERROR, RestAccessLogMwLogFormatErrorMessage, slate.LogContext{"error": e})
+				return
+			}
+			mutex.Lock()
+			formatter = updated
+			header = format
+			mutex.Unlock()
+		})
+	}
+	// return the middleware generator function
+	return func() RestMiddleware {
+		return func(
+			next gin.HandlerFunc,
+		) gin.HandlerFunc {
+			// return the middleware handler function
+			return func(
+				ctx *gin.Context,
+			) {
+				// execute the endpoint process and calculate the elapsed
+				// time of it
+				start := time.Now()
+				if next != nil {
+					next(ctx)
+				}
+				entry := &RestAccessLogEntry{
+					Context:  ctx,
+					Time:     start,
+					Duration: time.Since(start),
+					Redactor: redactor,
+				}
+				// send the format directives header, if not sent yet, and
+				// the formatted access log line
+				mutex.Lock()
+				format := formatter
+				for _, line := range restAccessLogHeader(header, start) {
+					_ = logger.Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, line)
+				}
+				header = ""
+				mutex.Unlock()
+				_ = logger.Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, format(entry), slate.LogContext{
+					RestRequestIDMwLogField: RestGetRequestID(ctx),
+				})
+			}
+		}
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Rest Access Log Middleware Service Register
+// ----------------------------------------------------------------------------
+
+// RestAccessLogMwServiceRegister defines the access log middleware provider
+// to be used on the application initialization to register the access log
+// middleware generator.
+type RestAccessLogMwServiceRegister struct {
+	slate.ServiceRegister
+}
+
+var _ slate.ServiceProvider = &RestAccessLogMwServiceRegister{}
+
+// NewRestAccessLogMwServiceRegister will generate a new registry instance
+func NewRestAccessLogMwServiceRegister(
+	app ...*slate.App,
+) *RestAccessLogMwServiceRegister {
+	return &RestAccessLogMwServiceRegister{
+		ServiceRegister: *slate.NewServiceRegister(app...),
+	}
+}
+
+// Provide will add to the container the access log middleware generator.
+func (RestAccessLogMwServiceRegister) Provide(
+	container *slate.ServiceContainer,
+) error {
+	// check container argument reference
+	if container == nil {
+		return errNilPointer("container")
+	}
+	_ = container.Add(RestAccessLogMwContainerID, NewRestAccessLogMwGenerator)
+	return nil
+}
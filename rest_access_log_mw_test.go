@@ -0,0 +1,298 @@
+package sapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/happyhippyhippo/slate"
+)
+
+func restAccessLogTestEntry() *RestAccessLogEntry {
+	gin.SetMode(gin.ReleaseMode)
+	writer := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(writer)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/items?page=2", nil)
+	ctx.Request.RemoteAddr = "10.0.0.1:1234"
+	ctx.Request.Header.Set("Referer", "http://referer")
+	ctx.Request.Header.Set("User-Agent", "agent 1.0")
+	ctx.Request.SetBasicAuth("user", "password")
+	ctx.Header("X-Response", "value")
+	ctx.String(http.StatusCreated, "response")
+	return &RestAccessLogEntry{
+		Context:  ctx,
+		Time:     time.Date(2023, 10, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600)),
+		Duration: 1500 * time.Millisecond,
+	}
+}
+
+func restAccessLogTestRedactor() *RestLogMwRedactor {
+	redactor, _ := NewRestLogMwRedactor(slate.NewConfig())
+	return redactor
+}
+
+func Test_RestAccessLogFormatter(t *testing.T) {
+	t.Run("NewRestAccessLogFormatter", func(t *testing.T) {
+		t.Run("invalid templates", func(t *testing.T) {
+			for _, format := range []string{"%", "%{header", "%{header}", "%z"} {
+				if sut, e := NewRestAccessLogFormatter(format); sut != nil {
+					t.Errorf("(%s) returned an unexpected valid reference", format)
+				} else if !errors.Is(e, slate.ErrConversion) {
+					t.Errorf("(%s) (%v) when expecting (%v)", format, e, slate.ErrConversion)
+				}
+			}
+		})
+
+		t.Run("format", func(t *testing.T) {
+			scenarios := []struct {
+				format   string
+				expected string
+			}{
+				{
+					format:   RestAccessLogFormatCommon,
+					expected: `10.0.0.1 - user [10/Oct/2023:13:55:36 -0700] "GET /items?page=2 HTTP/1.1" 201 8`,
+				},
+				{
+					format:   "COMBINED",
+					expected: `10.0.0.1 - user [10/Oct/2023:13:55:36 -0700] "GET /items?page=2 HTTP/1.1" 201 8 "http://referer" "agent 1.0"`,
+				},
+				{
+					format:   RestAccessLogFormatW3C,
+					expected: `2023-10-10 20:55:36 10.0.0.1 user GET /items page=2 201 8 1500 agent+1.0 http://referer`,
+				},
+				{
+					format:   `%m %U%q %H %s %B %D %T %{X-Response}o %{X-Missing}i 100%%`,
+					expected: `GET /items?page=2 HTTP/1.1 201 8 1500000 1 value - 100%`,
+				},
+			}
+
+			for _, s := range scenarios {
+				if sut, e := NewRestAccessLogFormatter(s.format); e != nil {
+					t.Errorf("(%s) unexpected (%v) error", s.format, e)
+				} else if check := sut(restAccessLogTestEntry()); check != s.expected {
+					t.Errorf("(%s) (%v) when expecting (%v)", s.format, check, s.expected)
+				}
+			}
+		})
+
+		t.Run("escape the logged values", func(t *testing.T) {
+			sut, _ := NewRestAccessLogFormatter(`"%r" "%{X-Value}i"`)
+			entry := restAccessLogTestEntry()
+			entry.Context.Request.URL.Path = "/items\r\n"
+			entry.Context.Request.Header.Set("X-Value", `x\"`+"\x01\x7f")
+
+			expected := `"GET /items%0D%0A?page=2 HTTP/1.1" "x\\\"\x01\x7f"`
+
+			if check := sut(entry); check != expected {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("redact the query params and request headers", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			redactor, _ := NewRestLogMwRedactor(restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"headers": []interface{}{"referer"},
+				"params":  []interface{}{"page"},
+			}))
+			scenarios := []struct {
+				format   string
+				expected string
+			}{
+				{
+					format:   RestAccessLogFormatCombined,
+					expected: `10.0.0.1 - user [10/Oct/2023:13:55:36 -0700] "GET /items?page=[REDACTED] HTTP/1.1" 201 8 "[REDACTED]" "agent 1.0"`,
+				},
+				{
+					format:   RestAccessLogFormatW3C,
+					expected: `2023-10-10 20:55:36 10.0.0.1 user GET /items page=[REDACTED] 201 8 1500 agent+1.0 [REDACTED]`,
+				},
+				{
+					format:   `%U%q`,
+					expected: `/items?page=[REDACTED]`,
+				},
+			}
+
+			for _, s := range scenarios {
+				sut, _ := NewRestAccessLogFormatter(s.format)
+				entry := restAccessLogTestEntry()
+				entry.Redactor = redactor
+				if check := sut(entry); check != s.expected {
+					t.Errorf("(%s) (%v) when expecting (%v)", s.format, check, s.expected)
+				}
+			}
+		})
+	})
+}
+
+func Test_RestAccessLogMwGenerator(t *testing.T) {
+	t.Run("NewRestAccessLogMwGenerator", func(t *testing.T) {
+		t.Run("nil config", func(t *testing.T) {
+			if sut, e := NewRestAccessLogMwGenerator(nil, slate.NewLog(), restAccessLogTestRedactor()); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil logger", func(t *testing.T) {
+			if sut, e := NewRestAccessLogMwGenerator(slate.NewConfig(), nil, restAccessLogTestRedactor()); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil redactor", func(t *testing.T) {
+			if sut, e := NewRestAccessLogMwGenerator(slate.NewConfig(), slate.NewLog(), nil); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("invalid format", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.access.format", "%z")
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(RestAccessLogMwChannel, slate.ERROR, RestAccessLogMwLogFormatErrorMessage, gomock.Any()).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			if sut, e := NewRestAccessLogMwGenerator(config, logger, restAccessLogTestRedactor()); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("send the access log line", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.access.format", "%m %U %>s")
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, "POST /items 204", slate.LogContext{RestRequestIDMwLogField: "request-id"}).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			generator, _ := NewRestAccessLogMwGenerator(config, logger, restAccessLogTestRedactor())
+			gin.SetMode(gin.ReleaseMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/items", nil)
+			ctx.Request.Header.Set(RestRequestIDMwHeader, "request-id")
+			generator()(func(ctx *gin.Context) {
+				ctx.Status(http.StatusNoContent)
+			})(ctx)
+		})
+
+		t.Run("send the W3C directives once", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.access.format", "w3c")
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			logWriter := NewMockLogWriter(ctrl)
+			gomock.InOrder(
+				logWriter.EXPECT().Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, "#Version: 1.0", gomock.Any()).Times(1),
+				logWriter.EXPECT().Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, gomock.Any(), gomock.Any()).Times(1),
+				logWriter.EXPECT().Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, "#Fields: "+restAccessLogW3CFields, gomock.Any()).Times(1),
+				logWriter.EXPECT().Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, gomock.Any(), gomock.Any()).Times(2),
+			)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			generator, _ := NewRestAccessLogMwGenerator(config, logger, restAccessLogTestRedactor())
+			gin.SetMode(gin.ReleaseMode)
+			for i := 0; i < 2; i++ {
+				ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+				generator()(nil)(ctx)
+			}
+		})
+
+		t.Run("use the updated format", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.access.format", "%m")
+			newPartial := slate.ConfigPartial{}
+			_, _ = newPartial.Set("slate.api.rest.log.access.format", "%U")
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+			newSource := NewMockConfigSupplier(ctrl)
+			newSource.EXPECT().Get("").Return(newPartial, nil).Times(1)
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id1", 0, supplier)
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(RestAccessLogMwChannel, RestAccessLogMwLevel, "/items", gomock.Any()).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			generator, _ := NewRestAccessLogMwGenerator(config, logger, restAccessLogTestRedactor())
+			_ = config.AddSupplier("id2", 1, newSource)
+			gin.SetMode(gin.ReleaseMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+			generator()(nil)(ctx)
+		})
+	})
+}
+
+func Test_RestAccessLogMwServiceRegister(t *testing.T) {
+	t.Run("NewRestAccessLogMwServiceRegister", func(t *testing.T) {
+		t.Run("create with app reference", func(t *testing.T) {
+			app := slate.NewApp()
+			if sut := NewRestAccessLogMwServiceRegister(app); sut == nil {
+				t.Error("didn't returned a valid reference")
+			} else if sut.App != app {
+				t.Error("didn't stored the app reference")
+			}
+		})
+	})
+
+	t.Run("Provide", func(t *testing.T) {
+		t.Run("nil container", func(t *testing.T) {
+			if e := NewRestAccessLogMwServiceRegister().Provide(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("retrieving the access log middleware generator", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			_ = slate.NewFileSystemServiceRegister().Provide(container)
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
+			_ = NewRestLogMwServiceRegister().Provide(container)
+			_ = NewRestAccessLogMwServiceRegister().Provide(container)
+
+			if sut, e := container.Get(RestAccessLogMwContainerID); e != nil {
+				t.Errorf("unexpected error (%v)", e)
+			} else if _, ok := sut.(RestAccessLogMwGenerator); !ok {
+				t.Error("didn't returned the access log middleware generator")
+			}
+		})
+	})
+}
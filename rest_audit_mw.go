@@ -0,0 +1,616 @@
+package sapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// RestAuditMwContainerID defines the default id used to register
+	// the application audit middleware and related services.
+	RestAuditMwContainerID = RestContainerID + ".audit.mw"
+
+	// RestAuditMwSinkContainerID defines the id used to register the
+	// audit records sink in the application container.
+	RestAuditMwSinkContainerID = RestAuditMwContainerID + ".sink"
+
+	// RestAuditMwEnvID defines the audit middleware module base
+	// environment variable name.
+	RestAuditMwEnvID = RestEnvID + "_AUDIT_MW"
+)
+
+var (
+	// RestAuditMwMethods defines the list of request methods that will
+	// generate an audit record.
+	RestAuditMwMethods = slate.EnvList(RestAuditMwEnvID+"_METHODS", []string{"POST", "PUT", "PATCH", "DELETE"})
+
+	// RestAuditMwChannel defines the channel id to be used when the audit
+	// log sink sends the audit records to the logger instance.
+	RestAuditMwChannel = slate.EnvString(RestAuditMwEnvID+"_CHANNEL", "audit")
+
+	// RestAuditMwLevel defines the logging level to be used when the audit
+	// log sink sends the audit records to the logger instance.
+	RestAuditMwLevel = envToLogLevel(RestAuditMwEnvID+"_LEVEL", slate.NOTICE)
+
+	// RestAuditMwMessage defines the logging message to be used when the
+	// audit log sink sends the audit records to the logger instance.
+	RestAuditMwMessage = slate.EnvString(RestAuditMwEnvID+"_MESSAGE", "Audit")
+
+	// RestAuditMwLogSinkErrorMessage defines the logging message used when
+	// an audit record could not be stored in the sink.
+	RestAuditMwLogSinkErrorMessage = slate.EnvString(RestAuditMwEnvID+"_LOG_SINK_ERROR_MESSAGE", "Unable to store audit record")
+
+	// RestAuditMwLogEndpointErrorMessage defines the logging message used
+	// when the audited endpoint id configuration is invalid.
+	RestAuditMwLogEndpointErrorMessage = slate.EnvString(RestAuditMwEnvID+"_LOG_ENDPOINT_ERROR_MESSAGE", "Invalid endpoint id")
+
+	// RestAuditMwActorContextField defines the context field used to store
+	// the identity of the actor of the audited request.
+	RestAuditMwActorContextField = slate.EnvString(RestAuditMwEnvID+"_ACTOR_CONTEXT_FIELD", "sapi_audit_actor")
+
+	// RestAuditMwTargetContextField defines the context field used to
+	// store the resource targeted by the audited request.
+	RestAuditMwTargetContextField = slate.EnvString(RestAuditMwEnvID+"_TARGET_CONTEXT_FIELD", "sapi_audit_target")
+
+	// RestAuditMwRdbTable defines the default table used by the relational
+	// database audit sink to store the audit records.
+	RestAuditMwRdbTable = slate.EnvString(RestAuditMwEnvID+"_RDB_TABLE", "audit_records")
+
+	// RestAuditMwRdbAppendAttempts defines the number of attempts made by
+	// the relational database audit sink to append a record when another
+	// instance concurrently extended the chain.
+	RestAuditMwRdbAppendAttempts = slate.EnvInt(RestAuditMwEnvID+"_RDB_APPEND_ATTEMPTS", 5)
+
+	// RestAuditMwBodyMaxSize defines the maximum number of request body
+	// bytes not read by the handler that will be consumed to compose the
+	// request digest. The records of the requests with a larger unread
+	// body are flagged as having a truncated digest.
+	RestAuditMwBodyMaxSize = slate.EnvInt(RestAuditMwEnvID+"_BODY_MAX_SIZE", 10*1024*1024)
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrRestAuditChain defines an error that denotes that an audit
+	// records chain was tampered.
+	ErrRestAuditChain = fmt.Errorf("audit records chain mismatch")
+)
+
+func errRestAuditChain(
+	index int,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrRestAuditChain, fmt.Sprintf("record %d", index), ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// Rest Audit Context Handlers
+// ----------------------------------------------------------------------------
+
+// RestSetAuditActor will store the identity of the actor of the request
+// in the context to be reported in the audit record. If no actor is
+// stored, the basic authentication user will be used.
+func RestSetAuditActor(
+	ctx *gin.Context,
+	actor string,
+) *gin.Context {
+	ctx.Set(RestAuditMwActorContextField, actor)
+	return ctx
+}
+
+func restGetAuditActor(
+	ctx *gin.Context,
+) string {
+	if actor := ctx.GetString(RestAuditMwActorContextField); actor != "" {
+		return actor
+	}
+	return ctx.GetString(gin.AuthUserKey)
+}
+
+// RestSetAuditTarget will store the resource targeted by the request in
+// the context to be reported in the audit record. If no target is stored,
+// the request path will be used.
+func RestSetAuditTarget(
+	ctx *gin.Context,
+	target string,
+) *gin.Context {
+	ctx.Set(RestAuditMwTargetContextField, target)
+	return ctx
+}
+
+func restGetAuditTarget(
+	ctx *gin.Context,
+) string {
+	if target := ctx.GetString(RestAuditMwTargetContextField); target != "" {
+		return target
+	}
+	if ctx.Request != nil && ctx.Request.URL != nil {
+		return ctx.Request.URL.Path
+	}
+	return ""
+}
+
+// ----------------------------------------------------------------------------
+// Rest Audit Record
+// ----------------------------------------------------------------------------
+
+// RestAuditRecord defines the information stored about an audited
+// request. The records are chained by storing the hash of the previous
+// record, so any change or removal of a record can be detected. The
+// Truncated flag marks a request digest that doesn't cover the full body.
+type RestAuditRecord struct {
+	Time       time.Time `json:"time"`
+	RequestID  string    `json:"requestId"`
+	Actor      string    `json:"actor"`
+	Endpoint   string    `json:"endpoint"`
+	EndpointID int       `json:"endpointId"`
+	Method     string    `json:"method"`
+	Target     string    `json:"target"`
+	Digest     string    `json:"digest"`
+	Truncated  bool      `json:"truncated"`
+	Status     int       `json:"status"`
+	Previous   string    `json:"previous"`
+	Hash       string    `json:"hash"`
+}
+
+// Chain will link the record to the given previous record hash, and
+// calculate the record hash.
+func (r *RestAuditRecord) Chain(
+	previous string,
+) *RestAuditRecord {
+	r.Previous = previous
+	r.Hash = r.digest()
+	return r
+}
+
+func (r *RestAuditRecord) digest() string {
+	// hash the record content without its own hash, with the time at
+	// the microsecond precision kept by the relational databases
+	c := *r
+	c.Hash = ""
+	c.Time = c.Time.UTC().Truncate(time.Microsecond)
+	raw, _ := json.Marshal(c)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// RestAuditVerify will check the integrity of a list of chained records,
+// given in their storing order, returning an error that identifies the
+// first record that doesn't match the chain.
+func RestAuditVerify(
+	records []*RestAuditRecord,
+) error {
+	for i, r := range records {
+		if i > 0 && r.Previous != records[i-1].Hash {
+			return errRestAuditChain(i)
+		}
+		if r.Hash != r.digest() {
+			return errRestAuditChain(i)
+		}
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Rest Audit Sink
+// ----------------------------------------------------------------------------
+
+// RestAuditSink defines the interface of the storage of the audit records.
+// The Append method must chain the record to the last stored record and
+// store it as a single atomic operation, so concurrent writers sharing the
+// storage extend the same chain.
+type RestAuditSink interface {
+	Append(record *RestAuditRecord) error
+}
+
+// RestAuditLogSink defines an audit sink that sends the audit records to
+// a dedicated logging channel. The chain is restarted on every
+// application start, as the logged records can't be read back.
+type RestAuditLogSink struct {
+	logger *slate.Log
+	mutex  sync.Locker
+	last   string
+}
+
+var _ RestAuditSink = &RestAuditLogSink{}
+
+// NewRestAuditLogSink will instantiate a new audit logging sink.
+func NewRestAuditLogSink(
+	logger *slate.Log,
+) (*RestAuditLogSink, error) {
+	// check logger argument reference
+	if logger == nil {
+		return nil, errNilPointer("logger")
+	}
+	return &RestAuditLogSink{
+		logger: logger,
+		mutex:  &sync.Mutex{},
+	}, nil
+}
+
+// Last will retrieve the hash of the last logged record.
+func (s *RestAuditLogSink) Last() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.last, nil
+}
+
+// Append will chain the record to the last logged record and send it to
+// the audit logging channel.
+func (s *RestAuditLogSink) Append(
+	record *RestAuditRecord,
+) error {
+	// check record argument reference
+	if record == nil {
+		return errNilPointer("record")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	record.Chain(s.last)
+	e := s.logger.Signal(RestAuditMwChannel, RestAuditMwLevel, RestAuditMwMessage, slate.LogContext{
+		"time":       record.Time.UTC().Format(time.RFC3339Nano),
+		"requestId":  record.RequestID,
+		"actor":      record.Actor,
+		"endpoint":   record.Endpoint,
+		"endpointId": record.EndpointID,
+		"method":     record.Method,
+		"target":     record.Target,
+		"digest":     record.Digest,
+		"truncated":  record.Truncated,
+		"status":     record.Status,
+		"previous":   record.Previous,
+		"hash":       record.Hash,
+	})
+	if e != nil {
+		return e
+	}
+	s.last = record.Hash
+	return nil
+}
+
+// RestAuditRdbRecord defines the relational database model of an audit
+// record, so it can be added to the application migrations.
+type RestAuditRdbRecord struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement"`
+	Time       time.Time
+	RequestID  string `gorm:"size:128"`
+	Actor      string `gorm:"size:255"`
+	Endpoint   string `gorm:"size:255"`
+	EndpointID int
+	Method     string `gorm:"size:16"`
+	Target     string `gorm:"size:2048"`
+	Digest     string `gorm:"size:64"`
+	Truncated  bool
+	Status     int
+	Previous   string `gorm:"size:64;uniqueIndex"`
+	Hash       string `gorm:"size:64;uniqueIndex"`
+}
+
+// RestAuditRdbSink defines an audit sink that stores the audit records
+// in a relational database table. The unique previous hash index
+// prevents two instances sharing the table from forking the chain.
+type RestAuditRdbSink struct {
+	db    *gorm.DB
+	table string
+	mutex sync.Locker
+}
+
+var _ RestAuditSink = &RestAuditRdbSink{}
+
+// NewRestAuditRdbSink will instantiate a new relational database audit
+// sink that stores the records in the given table, creating the table if
+// it does not exist.
+func NewRestAuditRdbSink(
+	db *gorm.DB,
+	table string,
+) (*RestAuditRdbSink, error) {
+	// check db argument reference
+	if db == nil {
+		return nil, errNilPointer("db")
+	}
+	if table == "" {
+		table = RestAuditMwRdbTable
+	}
+	// create the audit table if not present
+	if !db.Migrator().HasTable(table) {
+		if e := db.Table(table).AutoMigrate(&RestAuditRdbRecord{}); e != nil {
+			return nil, e
+		}
+	}
+	return &RestAuditRdbSink{
+		db:    db,
+		table: table,
+		mutex: &sync.Mutex{},
+	}, nil
+}
+
+// Last will retrieve the hash of the last stored record.
+func (s *RestAuditRdbSink) Last() (string, error) {
+	return s.last(s.db)
+}
+
+func (s *RestAuditRdbSink) last(
+	db *gorm.DB,
+) (string, error) {
+	var records []RestAuditRdbRecord
+	if e := db.Table(s.table).Order("id desc").Limit(1).Find(&records).Error; e != nil {
+		return "", e
+	}
+	if len(records) == 0 {
+		return "", nil
+	}
+	return records[0].Hash, nil
+}
+
+// Append will chain the record to the last stored record and store it in
+// the audit table, within a single transaction. If the store conflicts
+// with the chain concurrently extended by another instance, the append is
+// retried with the new chain tip.
+func (s *RestAuditRdbSink) Append(
+	record *RestAuditRecord,
+) error {
+	// check record argument reference
+	if record == nil {
+		return errNilPointer("record")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var e error
+	for attempt := 0; attempt < RestAuditMwRdbAppendAttempts; attempt++ {
+		last := ""
+		if e = s.db.Transaction(func(tx *gorm.DB) error {
+			// lock the chain tip while the record is stored
+			var e error
+			if last, e = s.last(tx.Clauses(clause.Locking{Strength: "UPDATE"})); e != nil {
+				return e
+			}
+			return s.write(tx, record.Chain(last))
+		}); e == nil {
+			return nil
+		}
+		// retry only if the failure was a conflict with a concurrent
+		// append, denoted by a duplicate key or a moved chain tip
+		if !errors.Is(e, gorm.ErrDuplicatedKey) {
+			if tip, te := s.last(s.db); te != nil || tip == last {
+				return e
+			}
+		}
+	}
+	return e
+}
+
+func (s *RestAuditRdbSink) write(
+	db *gorm.DB,
+	record *RestAuditRecord,
+) error {
+	return db.Table(s.table).Create(&RestAuditRdbRecord{
+		Time:       record.Time.UTC(),
+		RequestID:  record.RequestID,
+		Actor:      record.Actor,
+		Endpoint:   record.Endpoint,
+		EndpointID: record.EndpointID,
+		Method:     record.Method,
+		Target:     record.Target,
+		Digest:     record.Digest,
+		Truncated:  record.Truncated,
+		Status:     record.Status,
+		Previous:   record.Previous,
+		Hash:       record.Hash,
+	}).Error
+}
+
+// Records will retrieve all the stored records in their storing order,
+// so the chain integrity can be checked with the RestAuditVerify function.
+func (s *RestAuditRdbSink) Records() ([]*RestAuditRecord, error) {
+	var rows []RestAuditRdbRecord
+	if e := s.db.Table(s.table).Order("id asc").Find(&rows).Error; e != nil {
+		return nil, e
+	}
+	records := make([]*RestAuditRecord, len(rows))
+	for i, row := range rows {
+		records[i] = &RestAuditRecord{
+			Time:       row.Time,
+			RequestID:  row.RequestID,
+			Actor:      row.Actor,
+			Endpoint:   row.Endpoint,
+			EndpointID: row.EndpointID,
+			Method:     row.Method,
+			Target:     row.Target,
+			Digest:     row.Digest,
+			Truncated:  row.Truncated,
+			Status:     row.Status,
+			Previous:   row.Previous,
+			Hash:       row.Hash,
+		}
+	}
+	return records, nil
+}
+
+// ----------------------------------------------------------------------------
+// Rest Audit Middleware Generator
+// ----------------------------------------------------------------------------
+
+type restAuditMwBody struct {
+	io.Reader
+	io.Closer
+}
+
+// RestAuditMwGenerator defines the function used to generate the audit
+// middleware for the endpoint with the given name.
+type RestAuditMwGenerator func(endpoint string) (RestMiddleware, error)
+
+// NewRestAuditMwGenerator instantiates a new audit middleware generator.
+// The generated middlewares will store a chained audit record in the sink
+// for every mutating request, with the actor, endpoint, target resource,
+// request body digest and response status.
+func NewRestAuditMwGenerator(
+	config *slate.Config,
+	logger *slate.Log,
+	sink RestAuditSink,
+) (RestAuditMwGenerator, error) {
+	// check config argument reference
+	if config == nil {
+		return nil, errNilPointer("config")
+	}
+	// check logger argument reference
+	if logger == nil {
+		return nil, errNilPointer("logger")
+	}
+	// check sink argument reference
+	if sink == nil {
+		return nil, errNilPointer("sink")
+	}
+	mutex := &sync.Mutex{}
+	// compose the audited methods set
+	methods := map[string]bool{}
+	for _, method := range RestAuditMwMethods {
+		methods[strings.ToUpper(method)] = true
+	}
+	// return the middleware generator
+	return func(
+		endpoint string,
+	) (RestMiddleware, error) {
+		// retrieve the endpoint id integer value from the configuration
+		configPathEndpointID := fmt.Sprintf(RestEnvelopeMwConfigPathEndpointID, endpoint)
+		endpointID, e := config.Int(configPathEndpointID, 0)
+		if e != nil {
+			_ = logger.Signal(RestAuditMwChannel, slate.ERROR, RestAuditMwLogEndpointErrorMessage, slate.LogContext{"error": e})
+			return nil, e
+		}
+		// add a config observer for the endpoint id integer value
+		_ = config.AddObserver(configPathEndpointID, func(_ interface{}, new interface{}) {
+			tnew, ok := new.(int)
+			if !ok {
+				_ = logger.Signal(RestAuditMwChannel, slate.ERROR, RestAuditMwLogEndpointErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			mutex.Lock()
+			endpointID = tnew
+			mutex.Unlock()
+		})
+		// return the generated middleware function
+		return func(
+			next gin.HandlerFunc,
+		) gin.HandlerFunc {
+			// return the middleware handler function
+			return func(
+				ctx *gin.Context,
+			) {
+				// skip the non-mutating requests
+				if ctx.Request == nil || !methods[strings.ToUpper(ctx.Request.Method)] {
+					if next != nil {
+						next(ctx)
+					}
+					return
+				}
+				// digest the request body while it's consumed by the handler
+				digest := sha256.New()
+				body := ctx.Request.Body
+				if body != nil {
+					ctx.Request.Body = &restAuditMwBody{Reader: io.TeeReader(body, digest), Closer: body}
+				}
+				start := time.Now()
+				completed := false
+				// store the audit record even if the handler panics,
+				// letting the panic propagate to the recovery middleware
+				defer func() {
+					// digest the request body content not read by the
+					// handler, up to the configured limit, flagging the
+					// digest as truncated if the body exceeds it
+					truncated := false
+					if body != nil {
+						if n, _ := io.CopyN(digest, body, int64(RestAuditMwBodyMaxSize)); n == int64(RestAuditMwBodyMaxSize) {
+							n, _ := io.ReadFull(body, make([]byte, 1))
+							truncated = n != 0
+						}
+					}
+					// compose the audit record
+					status := ctx.Writer.Status()
+					if !completed && !ctx.Writer.Written() {
+						status = http.StatusInternalServerError
+					}
+					record := &RestAuditRecord{
+						Time:      start.UTC().Truncate(time.Microsecond),
+						RequestID: RestGetRequestID(ctx),
+						Actor:     restGetAuditActor(ctx),
+						Endpoint:  endpoint,
+						Method:    ctx.Request.Method,
+						Target:    restGetAuditTarget(ctx),
+						Digest:    hex.EncodeToString(digest.Sum(nil)),
+						Truncated: truncated,
+						Status:    status,
+					}
+					mutex.Lock()
+					record.EndpointID = endpointID
+					mutex.Unlock()
+					// chain and store the audit record in the sink
+					if e := sink.Append(record); e != nil {
+						_ = logger.Signal(RestAuditMwChannel, slate.ERROR, RestAuditMwLogSinkErrorMessage, slate.LogContext{"error": e, "record": record})
+					}
+				}()
+				if next != nil {
+					next(ctx)
+				}
+				completed = true
+			}
+		}, nil
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Rest Audit Middleware Service Register
+// ----------------------------------------------------------------------------
+
+// RestAuditMwServiceRegister defines the audit middleware provider to be
+// used on the application initialization to register the audit middleware
+// generator and the default logging audit sink.
+type RestAuditMwServiceRegister struct {
+	slate.ServiceRegister
+}
+
+var _ slate.ServiceProvider = &RestAuditMwServiceRegister{}
+
+// NewRestAuditMwServiceRegister will generate a new registry instance
+func NewRestAuditMwServiceRegister(
+	app ...*slate.App,
+) *RestAuditMwServiceRegister {
+	return &RestAuditMwServiceRegister{
+		ServiceRegister: *slate.NewServiceRegister(app...),
+	}
+}
+
+// Provide will add to the container the audit middleware generator and
+// the logging audit sink. The sink can be replaced by registering another
+// RestAuditSink instance with the same id.
+func (RestAuditMwServiceRegister) Provide(
+	container *slate.ServiceContainer,
+) error {
+	// check container argument reference
+	if container == nil {
+		return errNilPointer("container")
+	}
+	_ = container.Add(RestAuditMwSinkContainerID, func(logger *slate.Log) (RestAuditSink, error) {
+		return NewRestAuditLogSink(logger)
+	})
+	_ = container.Add(RestAuditMwContainerID, NewRestAuditMwGenerator)
+	return nil
+}
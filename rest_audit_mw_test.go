@@ -0,0 +1,557 @@
+package sapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/happyhippyhippo/slate"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type restAuditTestSink struct {
+	last    string
+	err     error
+	records []*RestAuditRecord
+}
+
+func (s *restAuditTestSink) Append(record *RestAuditRecord) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.records = append(s.records, record.Chain(s.last))
+	s.last = record.Hash
+	return nil
+}
+
+func restAuditTestDB(t *testing.T) *gorm.DB {
+	db, e := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if e != nil {
+		t.Fatalf("unexpected (%v) error", e)
+	}
+	return db
+}
+
+func restAuditTestDigest(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+func Test_RestAuditRecord(t *testing.T) {
+	t.Run("Chain", func(t *testing.T) {
+		sut := &RestAuditRecord{Time: time.Now(), Actor: "actor", Status: 201}
+
+		if sut.Chain("previous"); sut.Previous != "previous" {
+			t.Errorf("(%v) when expecting (previous)", sut.Previous)
+		} else if len(sut.Hash) != 64 {
+			t.Errorf("(%v) is not a valid hash", sut.Hash)
+		}
+	})
+
+	t.Run("RestAuditVerify", func(t *testing.T) {
+		chain := func() []*RestAuditRecord {
+			first := (&RestAuditRecord{Time: time.Unix(1, 0), Actor: "first"}).Chain("")
+			second := (&RestAuditRecord{Time: time.Unix(2, 0), Actor: "second"}).Chain(first.Hash)
+			third := (&RestAuditRecord{Time: time.Unix(3, 0), Actor: "third"}).Chain(second.Hash)
+			return []*RestAuditRecord{first, second, third}
+		}
+
+		t.Run("valid chain", func(t *testing.T) {
+			if e := RestAuditVerify(chain()); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			}
+		})
+
+		t.Run("changed record", func(t *testing.T) {
+			records := chain()
+			records[1].Actor = "changed"
+
+			if e := RestAuditVerify(records); !errors.Is(e, ErrRestAuditChain) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrRestAuditChain)
+			} else if !strings.Contains(e.Error(), "record 1") {
+				t.Errorf("(%v) didn't identified the changed record", e)
+			}
+		})
+
+		t.Run("removed record", func(t *testing.T) {
+			records := chain()
+
+			if e := RestAuditVerify([]*RestAuditRecord{records[0], records[2]}); !errors.Is(e, ErrRestAuditChain) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrRestAuditChain)
+			}
+		})
+	})
+}
+
+func Test_RestAuditLogSink(t *testing.T) {
+	t.Run("NewRestAuditLogSink", func(t *testing.T) {
+		t.Run("nil logger", func(t *testing.T) {
+			if _, e := NewRestAuditLogSink(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		t.Run("nil record", func(t *testing.T) {
+			sut, _ := NewRestAuditLogSink(slate.NewLog())
+
+			if e := sut.Append(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("logging error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expected := fmt.Errorf("error message")
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(RestAuditMwChannel, RestAuditMwLevel, RestAuditMwMessage, gomock.Any()).Return(expected).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+			sut, _ := NewRestAuditLogSink(logger)
+
+			if e := sut.Append(&RestAuditRecord{}); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			} else if last, _ := sut.Last(); last != "" {
+				t.Errorf("stored the (%v) last hash", last)
+			}
+		})
+
+		t.Run("log the chained records", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			first := &RestAuditRecord{Time: time.Unix(0, 0), Actor: "first", Status: 201}
+			second := &RestAuditRecord{Time: time.Unix(1, 0), Actor: "second", Status: 204}
+			logWriter := NewMockLogWriter(ctrl)
+			gomock.InOrder(
+				logWriter.
+					EXPECT().
+					Signal(RestAuditMwChannel, RestAuditMwLevel, RestAuditMwMessage, gomock.Any()).
+					DoAndReturn(func(_ string, _ slate.LogLevel, _ string, ctx ...slate.LogContext) error {
+						switch {
+						case ctx[0]["actor"] != "first":
+							t.Errorf("(%v) when expecting (first)", ctx[0]["actor"])
+						case ctx[0]["previous"] != "":
+							t.Errorf("(%v) when expecting an empty previous hash", ctx[0]["previous"])
+						case ctx[0]["hash"] != first.Hash:
+							t.Errorf("(%v) when expecting (%v)", ctx[0]["hash"], first.Hash)
+						}
+						return nil
+					}),
+				logWriter.
+					EXPECT().
+					Signal(RestAuditMwChannel, RestAuditMwLevel, RestAuditMwMessage, gomock.Any()).
+					DoAndReturn(func(_ string, _ slate.LogLevel, _ string, ctx ...slate.LogContext) error {
+						if ctx[0]["previous"] != first.Hash {
+							t.Errorf("(%v) when expecting (%v)", ctx[0]["previous"], first.Hash)
+						}
+						return nil
+					}),
+			)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+			sut, _ := NewRestAuditLogSink(logger)
+
+			if e := sut.Append(first); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if e := sut.Append(second); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if last, _ := sut.Last(); last != second.Hash {
+				t.Errorf("(%v) when expecting (%v)", last, second.Hash)
+			} else if e := RestAuditVerify([]*RestAuditRecord{first, second}); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			}
+		})
+	})
+}
+
+func Test_RestAuditRdbSink(t *testing.T) {
+	t.Run("NewRestAuditRdbSink", func(t *testing.T) {
+		t.Run("nil db", func(t *testing.T) {
+			if _, e := NewRestAuditRdbSink(nil, ""); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("create the default table", func(t *testing.T) {
+			db := restAuditTestDB(t)
+
+			if _, e := NewRestAuditRdbSink(db, ""); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if !db.Migrator().HasTable(RestAuditMwRdbTable) {
+				t.Error("didn't created the audit table")
+			}
+		})
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		t.Run("nil record", func(t *testing.T) {
+			sut, _ := NewRestAuditRdbSink(restAuditTestDB(t), "audit")
+
+			if e := sut.Append(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("store and read back a verifiable chain", func(t *testing.T) {
+			sut, _ := NewRestAuditRdbSink(restAuditTestDB(t), "audit")
+
+			if last, e := sut.Last(); e != nil || last != "" {
+				t.Errorf("(%v, %v) unexpected last hash of an empty table", last, e)
+			}
+			first := &RestAuditRecord{Time: time.Now(), Actor: "first", Method: "POST", Status: 201}
+			_ = sut.Append(first)
+			second := &RestAuditRecord{Time: time.Now(), Actor: "second", Method: "DELETE", Status: 204}
+			_ = sut.Append(second)
+
+			if last, e := sut.Last(); e != nil || last != second.Hash {
+				t.Errorf("(%v, %v) when expecting (%v)", last, e, second.Hash)
+			}
+			records, e := sut.Records()
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case len(records) != 2:
+				t.Errorf("(%d) unexpected number of records", len(records))
+			default:
+				if e := RestAuditVerify(records); e != nil {
+					t.Errorf("unexpected (%v) error", e)
+				}
+			}
+		})
+
+		t.Run("continue the chain extended by another sink", func(t *testing.T) {
+			db := restAuditTestDB(t)
+			sut1, _ := NewRestAuditRdbSink(db, "audit")
+			sut2, _ := NewRestAuditRdbSink(db, "audit")
+
+			for i, sut := range []*RestAuditRdbSink{sut1, sut2, sut1, sut2} {
+				if e := sut.Append(&RestAuditRecord{Time: time.Now(), Actor: fmt.Sprintf("actor%d", i)}); e != nil {
+					t.Errorf("unexpected (%v) error", e)
+				}
+			}
+
+			if records, e := sut1.Records(); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if len(records) != 4 {
+				t.Errorf("(%d) unexpected number of records", len(records))
+			} else if e := RestAuditVerify(records); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			}
+		})
+
+		t.Run("retry the append on a conflict with a concurrent append", func(t *testing.T) {
+			db := restAuditTestDB(t)
+			sut, _ := NewRestAuditRdbSink(db, "audit")
+			calls := 0
+			_ = db.Callback().Create().Before("gorm:create").Register("test:conflict", func(tx *gorm.DB) {
+				if calls++; calls == 1 {
+					_ = tx.AddError(gorm.ErrDuplicatedKey)
+				}
+			})
+
+			if e := sut.Append(&RestAuditRecord{Time: time.Now(), Actor: "actor"}); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if calls != 2 {
+				t.Errorf("(%d) unexpected number of store attempts", calls)
+			} else if records, _ := sut.Records(); len(records) != 1 {
+				t.Errorf("(%d) unexpected number of records", len(records))
+			}
+		})
+
+		t.Run("don't retry the append on a non conflicting error", func(t *testing.T) {
+			db := restAuditTestDB(t)
+			sut, _ := NewRestAuditRdbSink(db, "audit")
+			expected := fmt.Errorf("error message")
+			calls := 0
+			_ = db.Callback().Create().Before("gorm:create").Register("test:error", func(tx *gorm.DB) {
+				calls++
+				_ = tx.AddError(expected)
+			})
+
+			if e := sut.Append(&RestAuditRecord{Time: time.Now(), Actor: "actor"}); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			} else if calls != 1 {
+				t.Errorf("(%d) unexpected number of store attempts", calls)
+			}
+		})
+
+		t.Run("reject a forked chain", func(t *testing.T) {
+			sut, _ := NewRestAuditRdbSink(restAuditTestDB(t), "audit")
+			_ = sut.Append(&RestAuditRecord{Time: time.Now(), Actor: "first"})
+			fork := (&RestAuditRecord{Time: time.Now(), Actor: "fork"}).Chain("")
+
+			if e := sut.write(sut.db, fork); e == nil {
+				t.Error("didn't returned the expected error")
+			}
+		})
+	})
+}
+
+func Test_RestAuditMwGenerator(t *testing.T) {
+	run := func(mw RestMiddleware, method, body string, handler gin.HandlerFunc) *gin.Context {
+		gin.SetMode(gin.ReleaseMode)
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(method, "/items/1", strings.NewReader(body))
+		mw(handler)(ctx)
+		return ctx
+	}
+
+	t.Run("NewRestAuditMwGenerator", func(t *testing.T) {
+		t.Run("nil config", func(t *testing.T) {
+			if _, e := NewRestAuditMwGenerator(nil, slate.NewLog(), &restAuditTestSink{}); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil logger", func(t *testing.T) {
+			if _, e := NewRestAuditMwGenerator(slate.NewConfig(), nil, &restAuditTestSink{}); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil sink", func(t *testing.T) {
+			if _, e := NewRestAuditMwGenerator(slate.NewConfig(), slate.NewLog(), nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("invalid endpoint id", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.endpoints.endpoint.id", "string")
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			generator, _ := NewRestAuditMwGenerator(config, slate.NewLog(), &restAuditTestSink{})
+
+			if _, e := generator("endpoint"); !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("don't audit non-mutating requests", func(t *testing.T) {
+			sink := &restAuditTestSink{}
+			generator, _ := NewRestAuditMwGenerator(slate.NewConfig(), slate.NewLog(), sink)
+			mw, _ := generator("endpoint")
+
+			called := false
+			run(mw, http.MethodGet, "", func(*gin.Context) { called = true })
+			if !called {
+				t.Error("didn't called the next handler")
+			} else if len(sink.records) != 0 {
+				t.Errorf("(%v) unexpected audit records", sink.records)
+			}
+		})
+
+		t.Run("audit mutating requests", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.endpoints.endpoint.id", 12)
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			sink := &restAuditTestSink{last: "previous"}
+			generator, _ := NewRestAuditMwGenerator(config, slate.NewLog(), sink)
+			mw, _ := generator("endpoint")
+
+			run(mw, http.MethodPost, `{"field":"value"}`, func(ctx *gin.Context) {
+				// partially read the body to check the digest of the full content
+				buf := make([]byte, 4)
+				_, _ = io.ReadFull(ctx.Request.Body, buf)
+				RestSetAuditActor(ctx, "actor")
+				RestSetAuditTarget(ctx, "item:1")
+				RestSetRequestID(ctx, "request-id")
+				ctx.Status(http.StatusCreated)
+			})
+			run(mw, http.MethodDelete, "", func(ctx *gin.Context) {
+				ctx.Set(gin.AuthUserKey, "user")
+				ctx.Status(http.StatusNoContent)
+			})
+
+			if len(sink.records) != 2 {
+				t.Fatalf("(%d) unexpected number of records", len(sink.records))
+			}
+			first, second := sink.records[0], sink.records[1]
+			switch {
+			case first.Actor != "actor" || first.Target != "item:1" || first.RequestID != "request-id":
+				t.Errorf("(%v) unexpected record identification", first)
+			case first.Endpoint != "endpoint" || first.EndpointID != 12 || first.Method != http.MethodPost:
+				t.Errorf("(%v) unexpected record endpoint", first)
+			case first.Status != http.StatusCreated:
+				t.Errorf("(%v) when expecting (%v)", first.Status, http.StatusCreated)
+			case first.Digest != restAuditTestDigest(`{"field":"value"}`):
+				t.Errorf("(%v) unexpected request digest", first.Digest)
+			case first.Previous != "previous":
+				t.Errorf("(%v) when expecting (previous)", first.Previous)
+			case second.Actor != "user" || second.Target != "/items/1" || second.Status != http.StatusNoContent:
+				t.Errorf("(%v) unexpected record", second)
+			case RestAuditVerify(sink.records) != nil:
+				t.Errorf("(%v) unexpected broken chain", RestAuditVerify(sink.records))
+			}
+		})
+
+		t.Run("continue the chain extended by another generator", func(t *testing.T) {
+			sink := &restAuditTestSink{}
+			generator1, _ := NewRestAuditMwGenerator(slate.NewConfig(), slate.NewLog(), sink)
+			generator2, _ := NewRestAuditMwGenerator(slate.NewConfig(), slate.NewLog(), sink)
+			mw1, _ := generator1("endpoint")
+			mw2, _ := generator2("endpoint")
+
+			run(mw1, http.MethodPost, "", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+			run(mw2, http.MethodPost, "", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+			run(mw1, http.MethodPost, "", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+
+			if len(sink.records) != 3 {
+				t.Fatalf("(%d) unexpected number of records", len(sink.records))
+			} else if e := RestAuditVerify(sink.records); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			}
+		})
+
+		t.Run("audit a panicking handler", func(t *testing.T) {
+			sink := &restAuditTestSink{}
+			generator, _ := NewRestAuditMwGenerator(slate.NewConfig(), slate.NewLog(), sink)
+			mw, _ := generator("endpoint")
+
+			func() {
+				defer func() {
+					if r := recover(); r != "panic" {
+						t.Errorf("(%v) when expecting the propagated panic", r)
+					}
+				}()
+				run(mw, http.MethodPost, "body", func(*gin.Context) { panic("panic") })
+			}()
+
+			if len(sink.records) != 1 {
+				t.Fatalf("(%d) unexpected number of records", len(sink.records))
+			} else if sink.records[0].Status != http.StatusInternalServerError {
+				t.Errorf("(%v) when expecting (%v)", sink.records[0].Status, http.StatusInternalServerError)
+			} else if sink.records[0].Digest != restAuditTestDigest("body") {
+				t.Errorf("(%v) unexpected request digest", sink.records[0].Digest)
+			}
+		})
+
+		t.Run("limit the digested unread body", func(t *testing.T) {
+			prev := RestAuditMwBodyMaxSize
+			RestAuditMwBodyMaxSize = 4
+			defer func() { RestAuditMwBodyMaxSize = prev }()
+
+			sink := &restAuditTestSink{}
+			generator, _ := NewRestAuditMwGenerator(slate.NewConfig(), slate.NewLog(), sink)
+			mw, _ := generator("endpoint")
+
+			run(mw, http.MethodPost, "0123456789", func(ctx *gin.Context) {
+				buf := make([]byte, 2)
+				_, _ = io.ReadFull(ctx.Request.Body, buf)
+			})
+
+			if len(sink.records) != 1 {
+				t.Fatalf("(%d) unexpected number of records", len(sink.records))
+			} else if sink.records[0].Digest != restAuditTestDigest("012345") {
+				t.Errorf("(%v) unexpected request digest", sink.records[0].Digest)
+			} else if !sink.records[0].Truncated {
+				t.Error("didn't flagged the digest as truncated")
+			}
+		})
+
+		t.Run("don't flag a digest of a body within the limit", func(t *testing.T) {
+			prev := RestAuditMwBodyMaxSize
+			RestAuditMwBodyMaxSize = 4
+			defer func() { RestAuditMwBodyMaxSize = prev }()
+
+			sink := &restAuditTestSink{}
+			generator, _ := NewRestAuditMwGenerator(slate.NewConfig(), slate.NewLog(), sink)
+			mw, _ := generator("endpoint")
+
+			run(mw, http.MethodPost, "012345", func(ctx *gin.Context) {
+				buf := make([]byte, 2)
+				_, _ = io.ReadFull(ctx.Request.Body, buf)
+			})
+
+			if len(sink.records) != 1 {
+				t.Fatalf("(%d) unexpected number of records", len(sink.records))
+			} else if sink.records[0].Digest != restAuditTestDigest("012345") {
+				t.Errorf("(%v) unexpected request digest", sink.records[0].Digest)
+			} else if sink.records[0].Truncated {
+				t.Error("unexpectedly flagged the digest as truncated")
+			}
+		})
+
+		t.Run("log the sink errors", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(RestAuditMwChannel, slate.ERROR, RestAuditMwLogSinkErrorMessage, gomock.Any()).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+			sink := &restAuditTestSink{err: fmt.Errorf("error message")}
+			generator, _ := NewRestAuditMwGenerator(slate.NewConfig(), logger, sink)
+			mw, _ := generator("endpoint")
+
+			run(mw, http.MethodPut, "", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+		})
+	})
+}
+
+func Test_RestAuditMwServiceRegister(t *testing.T) {
+	t.Run("NewRestAuditMwServiceRegister", func(t *testing.T) {
+		t.Run("create with app reference", func(t *testing.T) {
+			app := slate.NewApp()
+			if sut := NewRestAuditMwServiceRegister(app); sut == nil {
+				t.Error("didn't returned a valid reference")
+			} else if sut.App != app {
+				t.Error("didn't stored the app reference")
+			}
+		})
+	})
+
+	t.Run("Provide", func(t *testing.T) {
+		t.Run("nil container", func(t *testing.T) {
+			if e := NewRestAuditMwServiceRegister().Provide(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("retrieving the audit middleware generator", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			_ = slate.NewFileSystemServiceRegister().Provide(container)
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
+			_ = NewRestAuditMwServiceRegister().Provide(container)
+
+			if sut, e := container.Get(RestAuditMwSinkContainerID); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if _, ok := sut.(*RestAuditLogSink); !ok {
+				t.Errorf("didn't returned the logging audit sink : %v", sut)
+			}
+
+			if sut, e := container.Get(RestAuditMwContainerID); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if _, ok := sut.(RestAuditMwGenerator); !ok {
+				t.Error("didn't returned the audit middleware generator")
+			}
+		})
+	})
+}
@@ -0,0 +1,178 @@
+package sapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// RestBindContainerID defines the default id used to register the
+	// application request binder.
+	RestBindContainerID = RestContainerID + ".bind"
+
+	// RestBindEnvID defines the request binder module base environment
+	// variable name.
+	RestBindEnvID = RestEnvID + "_BIND"
+)
+
+var (
+	// RestBindErrorCode defines the envelope error code used to report a
+	// request that could not be bound into the request structure. The
+	// default code is outside the range of the validation tags codes.
+	RestBindErrorCode = slate.EnvInt(RestBindEnvID+"_ERROR_CODE", 999)
+
+	// RestBindErrorMessage defines the envelope error message used to
+	// report a request that could not be bound into the request structure,
+	// followed by the request source (body, uri, query or header) that
+	// could not be decoded.
+	RestBindErrorMessage = slate.EnvString(RestBindEnvID+"_ERROR_MESSAGE", "invalid request")
+)
+
+// ----------------------------------------------------------------------------
+// Rest Binder
+// ----------------------------------------------------------------------------
+
+// RestBinder defines a function used to bind the request URI params,
+// query, headers and body into a request structure, and validate it.
+// The binder returns false if the request could not be bound or is not
+// valid, storing the failure envelope as the context response so the
+// handler can just return. The failure envelope error codes get the
+// endpoint service and endpoint sections from the envelope middleware.
+type RestBinder func(ctx *gin.Context, req interface{}) bool
+
+// NewRestBinder instantiates a new request binder that will validate the
+// bound requests with the given validator.
+func NewRestBinder(
+	validator ValidatorWithOptions,
+) (RestBinder, error) {
+	// check the validator argument reference
+	if validator == nil {
+		return nil, errNilPointer("validator")
+	}
+	// store the bind failure envelope, identifying the request source
+	// that could not be bound without exposing the decoding error
+	fail := func(ctx *gin.Context, source string) bool {
+		message := RestBindErrorMessage
+		if source != "" {
+			message = fmt.Sprintf("%s %s", message, source)
+		}
+		RestSetResponse(
+			ctx,
+			NewEnvelope(http.StatusBadRequest, nil).
+				AddError(NewEnvelopeStatusError(RestBindErrorCode, message)))
+		return false
+	}
+	// return the binder function
+	return func(
+		ctx *gin.Context,
+		req interface{},
+	) bool {
+		// bind the request body if present, by the request content type,
+		// before the remaining sources so the body can't override them
+		if restBindHasBody(ctx.Request) {
+			if e := ctx.ShouldBindWith(req, binding.Default(ctx.Request.Method, ctx.ContentType())); !restBindDecoded(e) {
+				return fail(ctx, "body")
+			}
+		}
+		// bind the request URI params, query and headers
+		if e := ctx.ShouldBindUri(req); !restBindDecoded(e) {
+			return fail(ctx, "uri")
+		}
+		if e := ctx.ShouldBindQuery(req); !restBindDecoded(e) {
+			return fail(ctx, "query")
+		}
+		if e := ctx.ShouldBindHeader(req); !restBindDecoded(e) {
+			return fail(ctx, "header")
+		}
+		// check the binding rules once all the sources are bound
+		if binding.Validator != nil {
+			if e := binding.Validator.ValidateStruct(req); e != nil {
+				return fail(ctx, "")
+			}
+		}
+		// validate the bound request with the request locale messages
+		env, e := validator(req, ValidationWithContext(ctx))
+		switch {
+		case e != nil:
+			RestSetResponse(ctx, e)
+			return false
+		case env != nil:
+			RestSetResponse(ctx, env)
+			return false
+		}
+		return true
+	}, nil
+}
+
+// RestBind will bind and validate the request into a new instance of the
+// given request structure type, returning false if the handler should
+// return without further processing.
+func RestBind[T any](
+	ctx *gin.Context,
+	binder RestBinder,
+) (*T, bool) {
+	req := new(T)
+	if !binder(ctx, req) {
+		return nil, false
+	}
+	return req, true
+}
+
+func restBindDecoded(
+	e error,
+) bool {
+	// the binding rules validation runs after the source is decoded,
+	// so the request is only validated when all the sources are bound
+	var errs validator.ValidationErrors
+	var slice binding.SliceValidationError
+	return e == nil || errors.As(e, &errs) || errors.As(e, &slice)
+}
+
+func restBindHasBody(
+	req *http.Request,
+) bool {
+	return req.Body != nil && req.Body != http.NoBody && req.ContentLength != 0
+}
+
+// ----------------------------------------------------------------------------
+// Rest Binder Service Register
+// ----------------------------------------------------------------------------
+
+// RestBindServiceRegister defines the service provider to be used on
+// the application initialization to register the request binder.
+type RestBindServiceRegister struct {
+	slate.ServiceRegister
+}
+
+var _ slate.ServiceProvider = &RestBindServiceRegister{}
+
+// NewRestBindServiceRegister will generate a new registry instance
+func NewRestBindServiceRegister(
+	app ...*slate.App,
+) *RestBindServiceRegister {
+	return &RestBindServiceRegister{
+		ServiceRegister: *slate.NewServiceRegister(app...),
+	}
+}
+
+// Provide will add to the container the request binder.
+func (RestBindServiceRegister) Provide(
+	container *slate.ServiceContainer,
+) error {
+	// check container argument reference
+	if container == nil {
+		return errNilPointer("container")
+	}
+	_ = container.Add(RestBindContainerID, NewRestBinder)
+	return nil
+}
@@ -0,0 +1,271 @@
+package sapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+type restBindTestRequest struct {
+	ID      int    `uri:"id" validate:"gt=0" vparam:"1"`
+	Page    int    `form:"page" validate:"gte=0" vparam:"2"`
+	Tenant  string `header:"X-Tenant" validate:"required" vparam:"3"`
+	Name    string `json:"name" xml:"name" form:"name" validate:"required" vparam:"4"`
+	Comment string `json:"comment" xml:"comment" form:"comment"`
+}
+
+func Test_RestBinder(t *testing.T) {
+	validator := func() ValidatorWithOptions {
+		universal := NewValidationUniversalTranslator()
+		translator, _ := NewValidationTranslator(universal)
+		parser, _ := NewValidationParser(translator)
+		validator, _ := NewValidatorWithRules(translator, parser, universal, nil, nil)
+		return validator
+	}()
+	request := func(method, target, contentType, body string) *gin.Context {
+		gin.SetMode(gin.ReleaseMode)
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(method, target, strings.NewReader(body))
+		if body == "" {
+			ctx.Request = httptest.NewRequest(method, target, nil)
+		}
+		ctx.Request.Header.Set("Content-Type", contentType)
+		ctx.Request.Header.Set("X-Tenant", "tenant")
+		ctx.Params = gin.Params{{Key: "id", Value: "12"}}
+		return ctx
+	}
+	failure := func(ctx *gin.Context) *Envelope {
+		response, ok := restGetResponse(ctx)
+		if !ok {
+			return nil
+		}
+		env, _ := response.(*Envelope)
+		return env
+	}
+
+	t.Run("NewRestBinder", func(t *testing.T) {
+		t.Run("nil validator", func(t *testing.T) {
+			if _, e := NewRestBinder(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+	})
+
+	sut, _ := NewRestBinder(validator)
+
+	t.Run("bind all the request sources", func(t *testing.T) {
+		scenarios := []struct {
+			name        string
+			contentType string
+			body        string
+		}{
+			{name: "json", contentType: gin.MIMEJSON, body: `{"name":"name","comment":"comment"}`},
+			{name: "xml", contentType: gin.MIMEXML, body: `<request><name>name</name><comment>comment</comment></request>`},
+			{name: "form", contentType: gin.MIMEPOSTForm, body: `name=name&comment=comment`},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				ctx := request(http.MethodPost, "/items/12?page=3", scenario.contentType, scenario.body)
+				req := restBindTestRequest{}
+
+				switch {
+				case !sut(ctx, &req):
+					t.Errorf("unexpected (%v) failure", failure(ctx))
+				case req.ID != 12 || req.Page != 3 || req.Tenant != "tenant":
+					t.Errorf("(%v) unexpected uri, query or header values", req)
+				case req.Name != "name" || req.Comment != "comment":
+					t.Errorf("(%v) unexpected body values", req)
+				}
+			})
+		}
+	})
+
+	t.Run("bind without body", func(t *testing.T) {
+		ctx := request(http.MethodGet, "/items/12?name=name", "", "")
+		req := restBindTestRequest{}
+
+		if !sut(ctx, &req) {
+			t.Errorf("unexpected (%v) failure", failure(ctx))
+		} else if req.Name != "name" {
+			t.Errorf("(%v) when expecting (name)", req.Name)
+		}
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		ctx := request(http.MethodPost, "/items/12", gin.MIMEJSON, `{"name":`)
+
+		if sut(ctx, &restBindTestRequest{}) {
+			t.Error("didn't failed the binding")
+		} else if env := failure(ctx); env == nil || env.GetStatusCode() != http.StatusBadRequest {
+			t.Errorf("unexpected (%v) response", env)
+		} else if env.Status.Errors[0].Error != fmt.Sprintf("%d", RestBindErrorCode) {
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Error, RestBindErrorCode)
+		} else if env.Status.Errors[0].Message != RestBindErrorMessage+" body" {
+			t.Errorf("(%v) when expecting (%v body)", env.Status.Errors[0].Message, RestBindErrorMessage)
+		} else if env.Status.Errors[0].Field != "" {
+			t.Errorf("(%v) unexpected field", env.Status.Errors[0].Field)
+		}
+	})
+
+	t.Run("bind error code doesn't collide with the validation codes", func(t *testing.T) {
+		parser, _ := NewValidationParser(NewValidationUniversalTranslator().GetFallback())
+
+		for tag, code := range parser.mapper {
+			if code == RestBindErrorCode {
+				t.Errorf("(%v) collides with the (%v) validation tag", code, tag)
+			}
+		}
+	})
+
+	t.Run("body doesn't override the uri params, query and headers", func(t *testing.T) {
+		ctx := request(http.MethodPost, "/items/12?page=3", gin.MIMEJSON, `{"ID":99,"Page":7,"Tenant":"other","name":"name"}`)
+		req := restBindTestRequest{}
+
+		switch {
+		case !sut(ctx, &req):
+			t.Errorf("unexpected (%v) failure", failure(ctx))
+		case req.ID != 12 || req.Page != 3 || req.Tenant != "tenant":
+			t.Errorf("(%v) unexpected uri, query or header values", req)
+		case req.Name != "name":
+			t.Errorf("(%v) unexpected body values", req)
+		}
+	})
+
+	t.Run("invalid uri param", func(t *testing.T) {
+		ctx := request(http.MethodPost, "/items/string", gin.MIMEJSON, `{"name":"name"}`)
+		ctx.Params = gin.Params{{Key: "id", Value: "string"}}
+
+		if sut(ctx, &restBindTestRequest{}) {
+			t.Error("didn't failed the binding")
+		} else if env := failure(ctx); env == nil || env.GetStatusCode() != http.StatusBadRequest {
+			t.Errorf("unexpected (%v) response", env)
+		} else if env.Status.Errors[0].Message != RestBindErrorMessage+" uri" {
+			t.Errorf("(%v) unexpected error", env.Status.Errors[0])
+		}
+	})
+
+	t.Run("invalid query value", func(t *testing.T) {
+		ctx := request(http.MethodPost, "/items/12?page=string", gin.MIMEJSON, `{"name":"name"}`)
+
+		if sut(ctx, &restBindTestRequest{}) {
+			t.Error("didn't failed the binding")
+		} else if env := failure(ctx); env == nil || env.GetStatusCode() != http.StatusBadRequest {
+			t.Errorf("unexpected (%v) response", env)
+		}
+	})
+
+	t.Run("check the binding rules after binding all the sources", func(t *testing.T) {
+		type bindingRequest struct {
+			ID     int    `uri:"id" binding:"required"`
+			Page   int    `form:"page" binding:"required"`
+			Tenant string `header:"X-Tenant" binding:"required"`
+			Name   string `json:"name" binding:"required"`
+		}
+
+		ctx := request(http.MethodPost, "/items/12?page=3", gin.MIMEJSON, `{"name":"name"}`)
+		req := bindingRequest{}
+		if !sut(ctx, &req) {
+			t.Errorf("unexpected (%v) failure", failure(ctx))
+		} else if req.ID != 12 || req.Page != 3 || req.Tenant != "tenant" || req.Name != "name" {
+			t.Errorf("(%v) unexpected request values", req)
+		}
+
+		ctx = request(http.MethodPost, "/items/12", gin.MIMEJSON, `{"name":"name"}`)
+		if sut(ctx, &bindingRequest{}) {
+			t.Error("didn't failed the binding")
+		} else if env := failure(ctx); env == nil || env.GetStatusCode() != http.StatusBadRequest {
+			t.Errorf("unexpected (%v) response", env)
+		} else if env.Status.Errors[0].Message != RestBindErrorMessage {
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Message, RestBindErrorMessage)
+		}
+	})
+
+	t.Run("validation failure", func(t *testing.T) {
+		ctx := request(http.MethodPost, "/items/12", gin.MIMEJSON, `{"comment":"comment"}`)
+		ctx.Request.Header.Del("X-Tenant")
+
+		if sut(ctx, &restBindTestRequest{}) {
+			t.Error("didn't failed the validation")
+		} else if env := failure(ctx); env == nil || len(env.Status.Errors) != 2 {
+			t.Errorf("unexpected (%v) response", env)
+		} else if env.Status.Errors[0].Param != 3 || env.Status.Errors[1].Param != 4 {
+			t.Errorf("(%v) unexpected errors", env.Status.Errors)
+		}
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		expected := fmt.Errorf("error message")
+		sut, _ := NewRestBinder(func(interface{}, ...ValidationOption) (*Envelope, error) {
+			return nil, expected
+		})
+		ctx := request(http.MethodPost, "/items/12", gin.MIMEJSON, `{"name":"name"}`)
+
+		if sut(ctx, &restBindTestRequest{}) {
+			t.Error("didn't failed the validation")
+		} else if response, _ := restGetResponse(ctx); response != expected {
+			t.Errorf("(%v) when expecting (%v)", response, expected)
+		}
+	})
+
+	t.Run("RestBind", func(t *testing.T) {
+		t.Run("return the typed request", func(t *testing.T) {
+			ctx := request(http.MethodPost, "/items/12", gin.MIMEJSON, `{"name":"name"}`)
+
+			if req, ok := RestBind[restBindTestRequest](ctx, sut); !ok {
+				t.Errorf("unexpected (%v) failure", failure(ctx))
+			} else if req.ID != 12 || req.Name != "name" {
+				t.Errorf("(%v) unexpected request", req)
+			}
+		})
+
+		t.Run("fail on invalid request", func(t *testing.T) {
+			ctx := request(http.MethodPost, "/items/12", gin.MIMEJSON, `{}`)
+
+			if req, ok := RestBind[restBindTestRequest](ctx, sut); ok || req != nil {
+				t.Errorf("unexpected (%v) request", req)
+			}
+		})
+	})
+}
+
+func Test_RestBindServiceRegister(t *testing.T) {
+	t.Run("NewRestBindServiceRegister", func(t *testing.T) {
+		t.Run("create with app reference", func(t *testing.T) {
+			app := slate.NewApp()
+			if sut := NewRestBindServiceRegister(app); sut == nil {
+				t.Error("didn't returned a valid reference")
+			} else if sut.App != app {
+				t.Error("didn't stored the app reference")
+			}
+		})
+	})
+
+	t.Run("Provide", func(t *testing.T) {
+		t.Run("nil container", func(t *testing.T) {
+			if e := NewRestBindServiceRegister().Provide(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("retrieving the request binder", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
+			_ = NewValidationServiceRegister().Provide(container)
+			_ = NewRestBindServiceRegister().Provide(container)
+
+			if sut, e := container.Get(RestBindContainerID); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if _, ok := sut.(RestBinder); !ok {
+				t.Error("didn't returned the request binder")
+			}
+		})
+	})
+}
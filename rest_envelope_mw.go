@@ -3,6 +3,7 @@ package sapi
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/happyhippyhippo/slate"
@@ -31,6 +32,24 @@ var (
 	// store the application accepted mime types formats.
 	RestEnvelopeMwConfigPathFormatAcceptList = slate.EnvString(RestEnvelopeMwEnvID+"_CONFIG_PATH_FORMAT_ACCEPT_LIST", "slate.api.rest.accept")
 
+	// RestEnvelopeMwConfigPathCodeFormat defines the config path that used
+	// to store the application error code format configuration.
+	RestEnvelopeMwConfigPathCodeFormat = slate.EnvString(RestEnvelopeMwEnvID+"_CONFIG_PATH_CODE_FORMAT", "slate.api.rest.service.code")
+
+	// RestEnvelopeMwConfigPathMeta defines the config path that used to
+	// store the flag that enables the envelope meta information section.
+	RestEnvelopeMwConfigPathMeta = slate.EnvString(RestEnvelopeMwEnvID+"_CONFIG_PATH_META", "slate.api.rest.meta")
+
+	// RestEnvelopeMwConfigPathVersion defines the config path that used to
+	// store the API version reported in the envelope meta information.
+	RestEnvelopeMwConfigPathVersion = slate.EnvString(RestEnvelopeMwEnvID+"_CONFIG_PATH_VERSION", "slate.api.rest.version")
+
+	// RestEnvelopeMwRequestIDHeader defines the request header used to
+	// obtain the request id reported in the envelope meta information.
+	// It is also the default request id middleware header, so the meta
+	// information and the log entries report the same request id.
+	RestEnvelopeMwRequestIDHeader = slate.EnvString(RestEnvelopeMwEnvID+"_REQUEST_ID_HEADER", "X-Request-Id")
+
 	// RestEnvelopeMwConfigPathEndpointID defines the format of the configuration
 	// path where the endpoint identification number can be retrieved.
 	RestEnvelopeMwConfigPathEndpointID = slate.EnvString(RestEnvelopeMwEnvID+"_CONFIG_PATH_ENDPOINT_ID", "slate.api.rest.endpoints.%s.id")
@@ -47,18 +66,32 @@ var (
 	// RestEnvelopeMwLogAcceptListErrorMessage @todo doc
 	RestEnvelopeMwLogAcceptListErrorMessage = slate.EnvString(RestEnvelopeMwEnvID+"_LOG_ACCEPT_LIST_ERROR_MESSAGE", "Invalid accept list")
 
+	// RestEnvelopeMwLogCodeFormatErrorMessage @todo doc
+	RestEnvelopeMwLogCodeFormatErrorMessage = slate.EnvString(RestEnvelopeMwEnvID+"_LOG_CODE_FORMAT_ERROR_MESSAGE", "Invalid code format")
+
+	// RestEnvelopeMwLogMetaErrorMessage @todo doc
+	RestEnvelopeMwLogMetaErrorMessage = slate.EnvString(RestEnvelopeMwEnvID+"_LOG_META_ERROR_MESSAGE", "Invalid meta flag")
+
+	// RestEnvelopeMwLogVersionErrorMessage @todo doc
+	RestEnvelopeMwLogVersionErrorMessage = slate.EnvString(RestEnvelopeMwEnvID+"_LOG_VERSION_ERROR_MESSAGE", "Invalid version")
+
 	// RestEnvelopeMwLogEndpointErrorMessage @todo doc
 	RestEnvelopeMwLogEndpointErrorMessage = slate.EnvString(RestEnvelopeMwEnvID+"_LOG_ENDPOINT_ERROR_MESSAGE", "Invalid endpoint id")
 
 	// RestEnvelopeMwContextField @todo doc
 	RestEnvelopeMwContextField = slate.EnvString(RestEnvelopeMwEnvID+"_CONTEXT_FIELD", "sapi_response")
+
+	// RestEnvelopeMwWarningsContextField defines the context field used to
+	// store the warnings added by the handlers to the response.
+	RestEnvelopeMwWarningsContextField = slate.EnvString(RestEnvelopeMwEnvID+"_WARNINGS_CONTEXT_FIELD", "sapi_warnings")
 )
 
 // ----------------------------------------------------------------------------
 // Rest Envelope Middleware Context Handlers
 // ----------------------------------------------------------------------------
 
-// RestSetResponse @todo doc
+// RestSetResponse will store the response to be enveloped in the context.
+// The response can be an envelope, a typed envelope or an error.
 func RestSetResponse(
 	ctx *gin.Context,
 	response interface{},
@@ -73,6 +106,27 @@ func restGetResponse(
 	return ctx.Get(RestEnvelopeMwContextField)
 }
 
+// RestAddWarning will store a non-fatal warning in the context to be
+// added to the response envelope meta information.
+func RestAddWarning(
+	ctx *gin.Context,
+	w *EnvelopeMetaWarning,
+) *gin.Context {
+	ctx.Set(RestEnvelopeMwWarningsContextField, append(restGetWarnings(ctx), w))
+	return ctx
+}
+
+func restGetWarnings(
+	ctx *gin.Context,
+) []*EnvelopeMetaWarning {
+	if val, exists := ctx.Get(RestEnvelopeMwWarningsContextField); exists {
+		if warnings, ok := val.([]*EnvelopeMetaWarning); ok {
+			return warnings
+		}
+	}
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 // Rest Envelope Middleware Generator
 // ----------------------------------------------------------------------------
@@ -153,6 +207,57 @@ func NewRestEnvelopeMwGenerator(
 			}
 		}
 	})
+	// retrieve the meta information section enabling flag
+	meta, e := config.Bool(RestEnvelopeMwConfigPathMeta, false)
+	if e != nil {
+		_ = log(RestEnvelopeMwLogMetaErrorMessage, slate.LogContext{"error": e})
+		return nil, e
+	}
+	// add a config observer for the meta information section enabling flag
+	_ = config.AddObserver(RestEnvelopeMwConfigPathMeta, func(old interface{}, new interface{}) {
+		// new value type check for boolean
+		tnew, ok := new.(bool)
+		if !ok {
+			_ = log(RestEnvelopeMwLogMetaErrorMessage, slate.LogContext{"value": new})
+			return
+		}
+		meta = tnew
+	})
+	// retrieve the API version reported in the meta information section
+	version, e := config.String(RestEnvelopeMwConfigPathVersion, "")
+	if e != nil {
+		_ = log(RestEnvelopeMwLogVersionErrorMessage, slate.LogContext{"error": e})
+		return nil, e
+	}
+	// add a config observer for the API version
+	_ = config.AddObserver(RestEnvelopeMwConfigPathVersion, func(old interface{}, new interface{}) {
+		// new value type check for string
+		tnew, ok := new.(string)
+		if !ok {
+			_ = log(RestEnvelopeMwLogVersionErrorMessage, slate.LogContext{"value": new})
+			return
+		}
+		version = tnew
+	})
+	// retrieve the service error code formatter if configured
+	var formatter EnvelopeCodeFormatter
+	if config.Has(RestEnvelopeMwConfigPathCodeFormat) {
+		codeFormat, _ := config.Get(RestEnvelopeMwConfigPathCodeFormat)
+		formatter, e = restEnvelopeMwCodeFormatter(codeFormat)
+		if e != nil {
+			_ = log(RestEnvelopeMwLogCodeFormatErrorMessage, slate.LogContext{"error": e})
+			return nil, e
+		}
+		// add a config observer for the error code format
+		_ = config.AddObserver(RestEnvelopeMwConfigPathCodeFormat, func(old interface{}, new interface{}) {
+			tnew, e := restEnvelopeMwCodeFormatter(new)
+			if e != nil {
+				_ = log(RestEnvelopeMwLogCodeFormatErrorMessage, slate.LogContext{"error": e})
+				return
+			}
+			formatter = tnew
+		})
+	}
 	// return the middleware generator
 	return func(
 		id string,
@@ -182,6 +287,8 @@ func NewRestEnvelopeMwGenerator(
 			return func(
 				ctx *gin.Context,
 			) {
+				// store the request processing starting time
+				start := time.Now()
 				// declare the result parsing method
 				parse := func(val interface{}) {
 					var response *Envelope
@@ -190,6 +297,10 @@ func NewRestEnvelopeMwGenerator(
 					case *Envelope:
 						// just set the result as the envelope reference
 						response = v
+					case EnvelopeConverter:
+						// convert the typed envelope into the envelope
+						// to be rendered
+						response = v.Envelope()
 					case error:
 						// set the result as a new envelope with an
 						// internal server error with the given error as the
@@ -204,6 +315,25 @@ func NewRestEnvelopeMwGenerator(
 							NewEnvelope(http.StatusInternalServerError, nil).
 								AddError(NewEnvelopeStatusError(0, "internal server error"))
 					}
+					// add the warnings stored by the handler in the context
+					for _, w := range restGetWarnings(ctx) {
+						response = response.AddWarning(w)
+					}
+					// fill the response meta information if enabled
+					if meta {
+						if response.Meta == nil {
+							response.Meta = NewEnvelopeMeta()
+						}
+						response.Meta.RequestID = RestGetRequestID(ctx)
+						response.Meta.ServerTime = time.Now().UTC().Format(time.RFC3339Nano)
+						response.Meta.Duration = float64(time.Since(start).Microseconds()) / 1000
+						response.Meta.Version = version
+					}
+					// apply the configured error code formatter, keeping any
+					// formatter assigned by the handler if none is configured
+					if formatter != nil {
+						response.SetFormatter(formatter)
+					}
 					// try to negotiate the response format with the defined
 					// accepted format mime types giving the response envelope
 					// as the content data of the response
@@ -234,6 +364,39 @@ func NewRestEnvelopeMwGenerator(
 	}, nil
 }
 
+func restEnvelopeMwCodeFormatter(
+	val interface{},
+) (EnvelopeCodeFormatter, error) {
+	// type check the code format configuration
+	partial, ok := val.(slate.ConfigPartial)
+	if !ok {
+		return nil, errConversion(val, "slate.ConfigPartial")
+	}
+	// parse the code format configuration
+	fc := struct {
+		Format    string
+		Separator string
+		Width     struct {
+			Service  int
+			Endpoint int
+			Param    int
+			Error    int
+		}
+	}{}
+	if _, e := partial.Populate("", &fc); e != nil {
+		return nil, e
+	}
+	// generate the configured formatter
+	return NewEnvelopeCodeFormatter(
+		fc.Format,
+		fc.Separator,
+		fc.Width.Service,
+		fc.Width.Endpoint,
+		fc.Width.Param,
+		fc.Width.Error,
+	)
+}
+
 // ----------------------------------------------------------------------------
 // Rest Envelope Middleware Service Register
 // ----------------------------------------------------------------------------
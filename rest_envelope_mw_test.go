@@ -1,10 +1,12 @@
 package sapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -40,6 +42,32 @@ func Test_RestGetResponse(t *testing.T) {
 	})
 }
 
+func Test_RestAddWarning(t *testing.T) {
+	t.Run("store the warnings", func(t *testing.T) {
+		ctx := &gin.Context{}
+		w1 := NewEnvelopeMetaWarning(1, "warning 1")
+		w2 := NewEnvelopeMetaWarning(2, "warning 2")
+
+		if chk := RestAddWarning(ctx, w1); chk != ctx {
+			t.Errorf("didn't returned the passed context")
+		}
+		_ = RestAddWarning(ctx, w2)
+
+		if check := restGetWarnings(ctx); !reflect.DeepEqual(check, []*EnvelopeMetaWarning{w1, w2}) {
+			t.Errorf("(%v) when expecting (%v)", check, []*EnvelopeMetaWarning{w1, w2})
+		}
+	})
+
+	t.Run("ignore invalid stored warnings", func(t *testing.T) {
+		ctx := &gin.Context{}
+		ctx.Set(RestEnvelopeMwWarningsContextField, "invalid")
+
+		if check := restGetWarnings(ctx); check != nil {
+			t.Errorf("unexpected (%v) warnings", check)
+		}
+	})
+}
+
 func Test_NewRestEnvelopeMwGenerator(t *testing.T) {
 	t.Run("nil config", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -703,6 +731,46 @@ func Test_NewRestEnvelopeMwGenerator(t *testing.T) {
 		}
 	})
 
+	t.Run("parse typed envelope stored in the response field of context", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		endpoint := "index"
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.service.id", 123)
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.endpoints.index.id", 456)
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+		generator, _ := NewRestEnvelopeMwGenerator(config, logger)
+		mw, _ := generator(endpoint)
+
+		handler := mw(func(ctx *gin.Context) {
+			RestSetResponse(ctx, NewTypedListEnvelope(400, []string{"data1"}, NewEnvelopeListReport("", 0, 1, 1)).
+				AddError(NewEnvelopeStatusError(1, "error message")))
+		})
+
+		gin.SetMode(gin.ReleaseMode)
+		writer := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(writer)
+		ctx.Request = &http.Request{}
+		handler(ctx)
+
+		expected := `{"status":{"success":false,"error":[{"code":"s:123.e:456.c:1","message":"error message"}]},`
+		expected += `"report":{"search":"","start":0,"count":1,"total":1,"prev":"","next":""},"data":["data1"]}`
+
+		if check := writer.Code; check != 400 {
+			t.Errorf("(%v) when expecting (400)", check)
+		} else if check := writer.Body.String(); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
 	t.Run("parse error stored in the response field of context", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -847,6 +915,362 @@ func Test_NewRestEnvelopeMwGenerator(t *testing.T) {
 		}
 	})
 
+	t.Run("error on invalid configured error code format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.service.code.format", "invalid")
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.
+			EXPECT().
+			Signal(RestEnvelopeMwLogChannel, slate.ERROR, RestEnvelopeMwLogCodeFormatErrorMessage, gomock.Any()).
+			Return(nil).
+			Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		generator, e := NewRestEnvelopeMwGenerator(config, logger)
+		switch {
+		case generator != nil:
+			t.Error("returned a valid reference")
+		case e == nil:
+			t.Error("didn't returned the expected error")
+		case !errors.Is(e, ErrUnknownEnvelopeCodeFormat):
+			t.Errorf("(%v) when expecting (%v)", e, ErrUnknownEnvelopeCodeFormat)
+		}
+	})
+
+	t.Run("compose the error codes with the configured format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		endpoint := "index"
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.service.id", 12)
+		_, _ = partial.Set("slate.api.rest.service.code", slate.ConfigPartial{
+			"format":    "padded",
+			"separator": "-",
+			"width":     slate.ConfigPartial{"error": 4},
+		})
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.endpoints.index.id", 34)
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logger := slate.NewLog()
+		generator, _ := NewRestEnvelopeMwGenerator(config, logger)
+		mw, _ := generator(endpoint)
+
+		handler := mw(func(ctx *gin.Context) {
+			RestSetResponse(ctx, NewEnvelope(400, nil).AddError(NewEnvelopeStatusError(104, "error message").SetParam(5)))
+		})
+
+		gin.SetMode(gin.ReleaseMode)
+		writer := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(writer)
+		ctx.Request = &http.Request{}
+		handler(ctx)
+
+		expected := `{"status":{"success":false,"error":[{"code":"012-034-05-0104","message":"error message"}]}}`
+
+		if check := writer.Body.String(); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
+	t.Run("keep the handler assigned formatter if no format is configured", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		endpoint := "index"
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.service.id", 12)
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.endpoints.index.id", 34)
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logger := slate.NewLog()
+		generator, _ := NewRestEnvelopeMwGenerator(config, logger)
+		mw, _ := generator(endpoint)
+		formatter, _ := NewEnvelopeCodeFormatter("padded", "-", 3, 3, 2, 4)
+
+		handler := mw(func(ctx *gin.Context) {
+			RestSetResponse(ctx, NewEnvelope(400, nil).AddError(NewEnvelopeStatusError(104, "error message").SetParam(5)).SetFormatter(formatter))
+		})
+
+		gin.SetMode(gin.ReleaseMode)
+		writer := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(writer)
+		ctx.Request = &http.Request{}
+		handler(ctx)
+
+		expected := `{"status":{"success":false,"error":[{"code":"012-034-05-0104","message":"error message"}]}}`
+
+		if check := writer.Body.String(); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
+	t.Run("registered observer update the error code format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		endpoint := "index"
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.service.id", 12)
+		_, _ = partial.Set("slate.api.rest.service.code.format", "std")
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.endpoints.index.id", 34)
+		newPartial := slate.ConfigPartial{}
+		_, _ = newPartial.Set("slate.api.rest.service.code.format", "padded")
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		newSource := NewMockConfigSupplier(ctrl)
+		newSource.EXPECT().Get("").Return(newPartial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id1", 0, supplier)
+		logger := slate.NewLog()
+		generator, _ := NewRestEnvelopeMwGenerator(config, logger)
+		mw, _ := generator(endpoint)
+
+		handler := mw(func(ctx *gin.Context) {
+			RestSetResponse(ctx, fmt.Errorf("error message"))
+		})
+
+		_ = config.AddSupplier("id2", 1, newSource)
+
+		gin.SetMode(gin.ReleaseMode)
+		writer := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(writer)
+		ctx.Request = &http.Request{}
+		handler(ctx)
+
+		expected := `{"status":{"success":false,"error":[{"code":"012-034-00-000","message":"error message"}]}}`
+
+		if check := writer.Body.String(); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
+	t.Run("registered code format observer log on invalid new format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.service.code.format", "std")
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		newPartial := slate.ConfigPartial{}
+		_, _ = newPartial.Set("slate.api.rest.service.code.format", "invalid")
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		newSource := NewMockConfigSupplier(ctrl)
+		newSource.EXPECT().Get("").Return(newPartial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id1", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.
+			EXPECT().
+			Signal(RestEnvelopeMwLogChannel, slate.ERROR, RestEnvelopeMwLogCodeFormatErrorMessage, gomock.Any()).
+			Return(nil).
+			Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+		_, _ = NewRestEnvelopeMwGenerator(config, logger)
+
+		_ = config.AddSupplier("id2", 1, newSource)
+	})
+
+	t.Run("error getting the meta flag from config", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.meta", "invalid")
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.
+			EXPECT().
+			Signal(RestEnvelopeMwLogChannel, slate.ERROR, RestEnvelopeMwLogMetaErrorMessage, gomock.Any()).
+			Return(nil).
+			Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		generator, e := NewRestEnvelopeMwGenerator(config, logger)
+		switch {
+		case generator != nil:
+			t.Error("returned a valid reference")
+		case e == nil:
+			t.Error("didn't returned the expected error")
+		case !errors.Is(e, slate.ErrConversion):
+			t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+		}
+	})
+
+	t.Run("error getting the version from config", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.version", 123)
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.
+			EXPECT().
+			Signal(RestEnvelopeMwLogChannel, slate.ERROR, RestEnvelopeMwLogVersionErrorMessage, gomock.Any()).
+			Return(nil).
+			Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		generator, e := NewRestEnvelopeMwGenerator(config, logger)
+		switch {
+		case generator != nil:
+			t.Error("returned a valid reference")
+		case e == nil:
+			t.Error("didn't returned the expected error")
+		case !errors.Is(e, slate.ErrConversion):
+			t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+		}
+	})
+
+	t.Run("add the context stored warnings to the response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		endpoint := "index"
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		generator, _ := NewRestEnvelopeMwGenerator(config, slate.NewLog())
+		mw, _ := generator(endpoint)
+
+		handler := mw(func(ctx *gin.Context) {
+			RestAddWarning(ctx, NewEnvelopeMetaWarning(1, "warning message"))
+			RestSetResponse(ctx, NewEnvelope(200, "data"))
+		})
+
+		gin.SetMode(gin.ReleaseMode)
+		writer := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(writer)
+		ctx.Request = &http.Request{}
+		handler(ctx)
+
+		expected := `{"status":{"success":true,"error":[]},"meta":{"warnings":[{"code":"1","message":"warning message"}]},"data":"data"}`
+
+		if check := writer.Body.String(); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
+	t.Run("fill the meta information if enabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		endpoint := "index"
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.meta", true)
+		_, _ = partial.Set("slate.api.rest.version", "v1.2.3")
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		generator, _ := NewRestEnvelopeMwGenerator(config, slate.NewLog())
+		mw, _ := generator(endpoint)
+
+		handler := mw(func(ctx *gin.Context) {
+			RestSetResponse(ctx, NewEnvelope(200, "data"))
+		})
+
+		gin.SetMode(gin.ReleaseMode)
+		writer := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(writer)
+		ctx.Request = &http.Request{Header: http.Header{}}
+		ctx.Request.Header.Set(RestEnvelopeMwRequestIDHeader, "request-id")
+		handler(ctx)
+
+		check := struct {
+			Meta *EnvelopeMeta `json:"meta"`
+		}{}
+		_ = json.Unmarshal(writer.Body.Bytes(), &check)
+		switch {
+		case check.Meta == nil:
+			t.Error("didn't filled the meta information")
+		case check.Meta.RequestID != "request-id":
+			t.Errorf("(%v) when expecting (request-id)", check.Meta.RequestID)
+		case check.Meta.Version != "v1.2.3":
+			t.Errorf("(%v) when expecting (v1.2.3)", check.Meta.Version)
+		case check.Meta.ServerTime == "":
+			t.Error("didn't filled the server time")
+		}
+	})
+
+	t.Run("registered observers update the meta information values", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		endpoint := "index"
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.accept", []interface{}{"application/json"})
+		_, _ = partial.Set("slate.api.rest.meta", false)
+		_, _ = partial.Set("slate.api.rest.version", "v1")
+		newPartial := slate.ConfigPartial{}
+		_, _ = newPartial.Set("slate.api.rest.meta", true)
+		_, _ = newPartial.Set("slate.api.rest.version", "v2")
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		newSource := NewMockConfigSupplier(ctrl)
+		newSource.EXPECT().Get("").Return(newPartial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id1", 0, supplier)
+		generator, _ := NewRestEnvelopeMwGenerator(config, slate.NewLog())
+		mw, _ := generator(endpoint)
+
+		handler := mw(func(ctx *gin.Context) {
+			RestSetResponse(ctx, NewEnvelope(200, "data"))
+		})
+
+		_ = config.AddSupplier("id2", 1, newSource)
+
+		gin.SetMode(gin.ReleaseMode)
+		writer := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(writer)
+		ctx.Request = &http.Request{}
+		handler(ctx)
+
+		check := struct {
+			Meta *EnvelopeMeta `json:"meta"`
+		}{}
+		_ = json.Unmarshal(writer.Body.Bytes(), &check)
+		switch {
+		case check.Meta == nil:
+			t.Error("didn't filled the meta information")
+		case check.Meta.Version != "v2":
+			t.Errorf("(%v) when expecting (v2)", check.Meta.Version)
+		}
+	})
+
 	t.Run("registered observer update the service id value", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -6,7 +6,9 @@ import (
 	"encoding/xml"
 	"io"
 	"net/http"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -55,10 +57,64 @@ var (
 	// to be used when the log middleware sends the logging signal to the
 	// logger instance.
 	RestLogMwResponseMessage = slate.EnvString(RestLogMwEnvID+"_RESPONSE_MESSAGE", "Response")
+
+	// RestLogMwResponseBodyAlways defines if the response body should be
+	// always logged, instead of only when the response status code differs
+	// from the endpoint expected status code.
+	RestLogMwResponseBodyAlways = slate.EnvBool(RestLogMwEnvID+"_RESPONSE_BODY_ALWAYS", false)
+
+	// RestLogMwConfigPathResponseBodyAlways defines the config path used to
+	// store the flag that overrides the RestLogMwResponseBodyAlways value
+	// on the readers generated by the log middleware readers factory.
+	RestLogMwConfigPathResponseBodyAlways = slate.EnvString(RestLogMwEnvID+"_CONFIG_PATH_RESPONSE_BODY_ALWAYS", "slate.api.rest.log.response.body_always")
+
+	// RestLogMwSlowChannel defines the channel id to be used when the
+	// log middleware sends the slow request logging signal to the logger
+	// instance.
+	RestLogMwSlowChannel = slate.EnvString(RestLogMwEnvID+"_SLOW_CHANNEL", "rest")
+
+	// RestLogMwSlowLevel defines the logging level to be used when the
+	// log middleware sends the slow request logging signal to the logger
+	// instance.
+	RestLogMwSlowLevel = envToLogLevel(RestLogMwEnvID+"_SLOW_LEVEL", slate.WARNING)
+
+	// RestLogMwSlowMessage defines the slow request event logging message
+	// to be used when the log middleware sends the logging signal to the
+	// logger instance.
+	RestLogMwSlowMessage = slate.EnvString(RestLogMwEnvID+"_SLOW_MESSAGE", "Slow request")
+
+	// RestLogMwBodyMaxSize defines the maximum number of bytes of the
+	// request and response bodies captured to be logged. A non-positive
+	// value will disable the capture size limit.
+	RestLogMwBodyMaxSize = slate.EnvInt(RestLogMwEnvID+"_BODY_MAX_SIZE", 64*1024)
+
+	// RestLogMwBodyTruncatedMarker defines the marker appended to the
+	// logged bodies that exceeded the maximum captured size.
+	RestLogMwBodyTruncatedMarker = slate.EnvString(RestLogMwEnvID+"_BODY_TRUNCATED_MARKER", "...[truncated]")
+
+	// RestLogMwBodySkippedMarker defines the value logged in place of the
+	// bodies with a content type marked to be skipped.
+	RestLogMwBodySkippedMarker = slate.EnvString(RestLogMwEnvID+"_BODY_SKIPPED_MARKER", "[skipped]")
+
+	// RestLogMwBodySkipContentTypes defines the list of content type
+	// prefixes of the bodies that will not be captured to be logged, like
+	// binary and streamed contents.
+	RestLogMwBodySkipContentTypes = slate.EnvList(RestLogMwEnvID+"_BODY_SKIP_CONTENT_TYPES", []string{
+		"application/octet-stream",
+		"application/pdf",
+		"application/zip",
+		"application/gzip",
+		"multipart/form-data",
+		"text/event-stream",
+		"image/",
+		"audio/",
+		"video/",
+		"font/",
+	})
 )
 
 func envToLogLevel(ev string, def slate.LogLevel) slate.LogLevel {
-	v, ok := slate.LogLevelMap[strings.ToLower(ev)]
+	v, ok := slate.LogLevelMap[strings.ToLower(os.Getenv(ev))]
 	if !ok {
 		return def
 	}
@@ -117,14 +173,50 @@ func getRequestHeaders(request *http.Request) slate.LogContext {
 }
 
 func getRequestBody(request *http.Request) string {
-	// obtain the request body (content destructible action)
+	// skip the capture of the request body if the content is
+	// marked as not to be logged
+	if request.Body != nil && isRestLogMwSkippedContentType(request.Header.Get("Content-Type")) {
+		return RestLogMwBodySkippedMarker
+	}
+	// obtain the request body up to the maximum captured size
+	// (content destructible action)
 	var bodyBytes []byte
 	if request.Body != nil {
-		bodyBytes, _ = io.ReadAll(request.Body)
+		if RestLogMwBodyMaxSize <= 0 {
+			bodyBytes, _ = io.ReadAll(request.Body)
+		} else {
+			bodyBytes, _ = io.ReadAll(io.LimitReader(request.Body, int64(RestLogMwBodyMaxSize)+1))
+		}
 	}
-	// reassign the request body with a memory buffer
-	request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-	return string(bodyBytes)
+	// reassign the request body with a memory buffer if fully read
+	if RestLogMwBodyMaxSize <= 0 || len(bodyBytes) <= RestLogMwBodyMaxSize {
+		request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		return string(bodyBytes)
+	}
+	// chain the captured content with the remaining unread request
+	// body, so the handler still reads the full stream
+	request.Body = &restLogMwRequestBody{
+		Reader: io.MultiReader(bytes.NewReader(bodyBytes), request.Body),
+		Closer: request.Body,
+	}
+	return string(bodyBytes[:RestLogMwBodyMaxSize]) + RestLogMwBodyTruncatedMarker
+}
+
+type restLogMwRequestBody struct {
+	io.Reader
+	io.Closer
+}
+
+func isRestLogMwSkippedContentType(
+	contentType string,
+) bool {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	for _, skip := range RestLogMwBodySkipContentTypes {
+		if skip = strings.ToLower(strings.TrimSpace(skip)); skip != "" && strings.HasPrefix(contentType, skip) {
+			return true
+		}
+	}
+	return false
 }
 
 // ----------------------------------------------------------------------------
@@ -137,6 +229,15 @@ func getRequestBody(request *http.Request) string {
 func NewRestLogMwRequestReaderJSONDecorator(
 	reader RestLogMwRequestReader,
 	model interface{},
+) (RestLogMwRequestReader, error) {
+	return newRestLogMwRequestReaderJSONDecorator(reader, func() interface{} {
+		return model
+	})
+}
+
+func newRestLogMwRequestReaderJSONDecorator(
+	reader RestLogMwRequestReader,
+	newModel func() interface{},
 ) (RestLogMwRequestReader, error) {
 	// check the reader argument reference
 	if reader == nil {
@@ -160,6 +261,7 @@ func NewRestLogMwRequestReaderJSONDecorator(
 		// bodyJson field
 		contentType := strings.ToLower(ctx.Request.Header.Get("Content-Type"))
 		if strings.HasPrefix(contentType, gin.MIMEJSON) {
+			model := newModel()
 			if e = json.Unmarshal([]byte(data["body"].(string)), &model); e == nil {
 				data["bodyJson"] = model
 			}
@@ -179,6 +281,15 @@ func NewRestLogMwRequestReaderJSONDecorator(
 func NewRestLogMwRequestReaderXMLDecorator(
 	reader RestLogMwRequestReader,
 	model interface{},
+) (RestLogMwRequestReader, error) {
+	return newRestLogMwRequestReaderXMLDecorator(reader, func() interface{} {
+		return model
+	})
+}
+
+func newRestLogMwRequestReaderXMLDecorator(
+	reader RestLogMwRequestReader,
+	newModel func() interface{},
 ) (RestLogMwRequestReader, error) {
 	// check the reader argument reference
 	if reader == nil {
@@ -202,6 +313,7 @@ func NewRestLogMwRequestReaderXMLDecorator(
 		// bodyXml field
 		contentType := strings.ToLower(ctx.Request.Header.Get("Content-Type"))
 		if strings.HasPrefix(contentType, gin.MIMEXML) || strings.HasPrefix(contentType, gin.MIMEXML2) {
+			model := newModel()
 			if err = xml.Unmarshal([]byte(data["body"].(string)), &model); err == nil {
 				data["bodyXml"] = model
 			}
@@ -221,7 +333,9 @@ type bodyHolder interface {
 
 type restLogMwResponseWriter struct {
 	gin.ResponseWriter
-	body *bytes.Buffer
+	body      *bytes.Buffer
+	limit     int
+	truncated bool
 }
 
 var _ gin.ResponseWriter = &restLogMwResponseWriter{}
@@ -237,24 +351,41 @@ func newRestLogMwResponseWriter(
 	return &restLogMwResponseWriter{
 		ResponseWriter: w,
 		body:           &bytes.Buffer{},
+		limit:          RestLogMwBodyMaxSize,
+		truncated:      false,
 	}, nil
 }
 
 // Write executes the writing the desired bytes into the underlying writer
-// and storing them in the internal buffer.
-func (w restLogMwResponseWriter) Write(
+// and storing them, up to the maximum captured size, in the internal
+// buffer.
+func (w *restLogMwResponseWriter) Write(
 	b []byte,
 ) (int, error) {
-	// write the content in the local body copy and
-	// in the default response writer
-	w.body.Write(b)
+	// write the content in the local body copy, limited to the
+	// maximum captured size, and in the default response writer
+	capture := b
+	if w.limit > 0 {
+		if remaining := w.limit - w.body.Len(); remaining < len(capture) {
+			if remaining < 0 {
+				remaining = 0
+			}
+			capture = capture[:remaining]
+			w.truncated = true
+		}
+	}
+	w.body.Write(capture)
 	return w.ResponseWriter.Write(b)
 }
 
 // Body will retrieve the stored bytes given on the previous calls
-// to the Write method.
-func (w restLogMwResponseWriter) Body() []byte {
+// to the Write method, with the truncation marker if the written
+// content exceeded the maximum captured size.
+func (w *restLogMwResponseWriter) Body() []byte {
 	// get the local copy of the response body
+	if w.truncated {
+		return append(append([]byte{}, w.body.Bytes()...), RestLogMwBodyTruncatedMarker...)
+	}
 	return w.body.Bytes()
 }
 
@@ -267,8 +398,19 @@ func (w restLogMwResponseWriter) Body() []byte {
 // response event.
 type RestLogMwResponseReader func(ctx *gin.Context, writer gin.ResponseWriter, statusCode int) (slate.LogContext, error)
 
-// NewRestLogMwResponseReader @todo doc.
+// NewRestLogMwResponseReader is the default function used to parse the
+// response context information. The response body is only logged if the
+// response status code differs from the expected, unless the
+// RestLogMwResponseBodyAlways flag is set.
 func NewRestLogMwResponseReader() RestLogMwResponseReader {
+	return newRestLogMwResponseReader(func() bool {
+		return RestLogMwResponseBodyAlways
+	})
+}
+
+func newRestLogMwResponseReader(
+	bodyAlways func() bool,
+) RestLogMwResponseReader {
 	return func(
 		_ *gin.Context,
 		writer gin.ResponseWriter,
@@ -281,15 +423,22 @@ func NewRestLogMwResponseReader() RestLogMwResponseReader {
 		// obtain the response status code
 		status := writer.Status()
 		// store the default logging information
+		headers := getResponseHeaders(writer)
 		data := slate.LogContext{
 			"status":  status,
-			"headers": getResponseHeaders(writer),
+			"headers": headers,
 		}
 		// add the response body to the logging information if the
-		// response status code differs from the expected
-		if status != statusCode {
+		// response status code differs from the expected, or if the
+		// body should always be logged
+		if status != statusCode || bodyAlways() {
 			if tw, ok := writer.(bodyHolder); ok {
-				data["body"] = string(tw.Body())
+				contentType, _ := headers["Content-Type"].(string)
+				if isRestLogMwSkippedContentType(contentType) {
+					data["body"] = RestLogMwBodySkippedMarker
+				} else {
+					data["body"] = string(tw.Body())
+				}
 			}
 		}
 		// return the response logging information
@@ -313,16 +462,42 @@ func getResponseHeaders(
 	return headers
 }
 
+func getResponseContentType(
+	ctx *gin.Context,
+	writer gin.ResponseWriter,
+) string {
+	// obtain the response content type, falling back to the request
+	// accepted types if the response does not define it
+	if contentType := writer.Header().Get("Content-Type"); contentType != "" {
+		return strings.ToLower(contentType)
+	}
+	if ctx.Request == nil {
+		return ""
+	}
+	return strings.ToLower(ctx.Request.Header.Get("Accept"))
+}
+
 // ----------------------------------------------------------------------------
 // Rest Log Middleware Response Reader JSON Decorator
 // ----------------------------------------------------------------------------
 
 // NewRestLogMwResponseReaderJSONDecorator will instantiate a new response
-// event context reader JSON decorator used to parse the response   body as
-// a JSON and add the parsed content into the logging data.
+// event context reader JSON decorator used to parse the response body as
+// a JSON and add the parsed content into the logging data. The response
+// format is obtained from the response Content-Type header, falling back
+// to the request Accept header if the response does not define it.
 func NewRestLogMwResponseReaderJSONDecorator(
 	reader RestLogMwResponseReader,
 	model interface{},
+) (RestLogMwResponseReader, error) {
+	return newRestLogMwResponseReaderJSONDecorator(reader, func() interface{} {
+		return model
+	})
+}
+
+func newRestLogMwResponseReaderJSONDecorator(
+	reader RestLogMwResponseReader,
+	newModel func() interface{},
 ) (RestLogMwResponseReader, error) {
 	// check the reader argument reference
 	if reader == nil {
@@ -351,8 +526,9 @@ func NewRestLogMwResponseReaderJSONDecorator(
 		// and try to unmarshall it if the response is in JSON to be logged
 		// in the bodyJson field
 		if body, ok := data["body"]; ok == true {
-			accept := strings.ToLower(ctx.Request.Header.Get("Accept"))
-			if accept == "*/*" || strings.Contains(accept, gin.MIMEJSON) {
+			contentType := getResponseContentType(ctx, writer)
+			if contentType == "*/*" || strings.Contains(contentType, "json") {
+				model := newModel()
 				if err = json.Unmarshal([]byte(body.(string)), &model); err == nil {
 					data["bodyJson"] = model
 				}
@@ -369,10 +545,21 @@ func NewRestLogMwResponseReaderJSONDecorator(
 
 // NewRestLogMwResponseReaderXMLDecorator will instantiate a new response
 // event context reader XML decorator used to parse the response body as an XML
-// and add the parsed content into the logging data.
+// and add the parsed content into the logging data. The response format is
+// obtained from the response Content-Type header, falling back to the
+// request Accept header if the response does not define it.
 func NewRestLogMwResponseReaderXMLDecorator(
 	reader RestLogMwResponseReader,
 	model interface{},
+) (RestLogMwResponseReader, error) {
+	return newRestLogMwResponseReaderXMLDecorator(reader, func() interface{} {
+		return model
+	})
+}
+
+func newRestLogMwResponseReaderXMLDecorator(
+	reader RestLogMwResponseReader,
+	newModel func() interface{},
 ) (RestLogMwResponseReader, error) {
 	// check the reader argument reference
 	if reader == nil {
@@ -401,8 +588,9 @@ func NewRestLogMwResponseReaderXMLDecorator(
 		// and try to unmarshall it if the response is in XML to be logged
 		// in the bodyXml field
 		if body, ok := data["body"]; ok == true {
-			accept := strings.ToLower(ctx.Request.Header.Get("Accept"))
-			if strings.Contains(accept, gin.MIMEXML) || strings.Contains(accept, gin.MIMEXML2) {
+			contentType := getResponseContentType(ctx, writer)
+			if strings.Contains(contentType, gin.MIMEXML) || strings.Contains(contentType, gin.MIMEXML2) {
+				model := newModel()
 				if err = xml.Unmarshal([]byte(body.(string)), &model); err == nil {
 					data["bodyXml"] = model
 				}
@@ -417,10 +605,13 @@ func NewRestLogMwResponseReaderXMLDecorator(
 // Rest Log Middleware Generator
 // ----------------------------------------------------------------------------
 
-// RestLogMwGenerator @todo doc
+// RestLogMwGenerator defines the function used to generate the log
+// middleware for an endpoint with the given expected status code.
 type RestLogMwGenerator func(statusCode int) RestMiddleware
 
-// NewRestLogMwGenerator @todo doc
+// NewRestLogMwGenerator instantiates a new log middleware generator.
+// The generated middlewares will log the request and response of all the
+// handled requests.
 func NewRestLogMwGenerator(
 	logger *slate.Log,
 	requestReader RestLogMwRequestReader,
@@ -438,53 +629,247 @@ func NewRestLogMwGenerator(
 	if responseReader == nil {
 		return nil, errNilPointer("responseReader")
 	}
+	// use the default logging rules and signals
+	settings := &restLogMwSettings{mutex: &sync.Mutex{}}
+	settings.rules, _ = newRestLogMwRules(nil)
 	// return the middleware generator function
 	return func(
 		statusCode int,
 	) RestMiddleware {
-		// return the middleware method with the expected status code
+		return restLogMwMiddleware(logger, settings, requestReader, responseReader, statusCode)
+	}, nil
+}
+
+// NewRestLogMwConfigGenerator instantiates a new log middleware generator
+// that follows the exclusion, sampling and level rules defined in the
+// configuration. The requests that exceed the endpoint slow threshold will
+// also be signaled with the timing breakdown of the request processing.
+// The duration and threshold fields are given in milliseconds, while the
+// duration_ns and timing breakdown fields are given in nanoseconds.
+func NewRestLogMwConfigGenerator(
+	config *slate.Config,
+	logger *slate.Log,
+	requestReader RestLogMwRequestReader,
+	responseReader RestLogMwResponseReader,
+) (RestLogMwGenerator, error) {
+	// check config argument reference
+	if config == nil {
+		return nil, errNilPointer("config")
+	}
+	// check logger argument reference
+	if logger == nil {
+		return nil, errNilPointer("logger")
+	}
+	// check request reader argument reference
+	if requestReader == nil {
+		return nil, errNilPointer("requestReader")
+	}
+	// check response reader argument reference
+	if responseReader == nil {
+		return nil, errNilPointer("responseReader")
+	}
+	// retrieve the logging rules and signals from the configuration
+	settings, e := newRestLogMwSettings(config, logger)
+	if e != nil {
+		return nil, e
+	}
+	// return the middleware generator function
+	return func(
+		statusCode int,
+	) RestMiddleware {
+		return restLogMwMiddleware(logger, settings, requestReader, responseReader, statusCode)
+	}, nil
+}
+
+type restLogMwSettings struct {
+	mutex   sync.Locker
+	rules   *restLogMwRules
+	signals *restLogMwSignals
+}
+
+func newRestLogMwSettings(
+	config *slate.Config,
+	logger *slate.Log,
+) (*restLogMwSettings, error) {
+	settings := &restLogMwSettings{mutex: &sync.Mutex{}}
+	settings.rules, _ = newRestLogMwRules(nil)
+	// retrieve the logging rules from the configuration
+	if config.Has(RestLogMwConfigPathRules) {
+		partial, e := config.Partial(RestLogMwConfigPathRules)
+		if e == nil {
+			settings.rules, e = newRestLogMwRules(partial)
+		}
+		if e != nil {
+			_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogRulesErrorMessage, slate.LogContext{"error": e})
+			return nil, e
+		}
+		// add a config observer for the logging rules
+		_ = config.AddObserver(RestLogMwConfigPathRules, func(_ interface{}, new interface{}) {
+			partial, ok := new.(slate.ConfigPartial)
+			if !ok {
+				_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogRulesErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			updated, e := newRestLogMwRules(partial)
+			if e != nil {
+				_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogRulesErrorMessage, slate.LogContext{"error": e})
+				return
+			}
+			settings.mutex.Lock()
+			settings.rules = updated
+			settings.mutex.Unlock()
+		})
+	}
+	// retrieve the logging signals channels and levels from the configuration
+	if config.Has(RestLogMwConfigPathSignals) {
+		partial, e := config.Partial(RestLogMwConfigPathSignals)
+		if e == nil {
+			settings.signals, e = newRestLogMwSignals(partial)
+		}
+		if e != nil {
+			_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogSignalsErrorMessage, slate.LogContext{"error": e})
+			return nil, e
+		}
+		// add a config observer for the logging signals
+		_ = config.AddObserver(RestLogMwConfigPathSignals, func(_ interface{}, new interface{}) {
+			partial, ok := new.(slate.ConfigPartial)
+			if !ok {
+				_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogSignalsErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			updated, e := newRestLogMwSignals(partial)
+			if e != nil {
+				_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogSignalsErrorMessage, slate.LogContext{"error": e})
+				return
+			}
+			settings.mutex.Lock()
+			settings.signals = updated
+			settings.mutex.Unlock()
+		})
+	}
+	return settings, nil
+}
+
+func (s *restLogMwSettings) get() (*restLogMwRules, *restLogMwSignals) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// use the default signals if no signals configuration is present
+	signals := s.signals
+	if signals == nil {
+		signals, _ = newRestLogMwSignals(nil)
+	}
+	return s.rules, signals
+}
+
+func restLogMwMiddleware(
+	logger *slate.Log,
+	settings *restLogMwSettings,
+	requestReader RestLogMwRequestReader,
+	responseReader RestLogMwResponseReader,
+	statusCode int,
+) RestMiddleware {
+	// return the middleware method with the expected status code
+	return func(
+		next gin.HandlerFunc,
+	) gin.HandlerFunc {
+		// return the middleware handler function
 		return func(
-			next gin.HandlerFunc,
-		) gin.HandlerFunc {
-			// return the middleware handler function
-			return func(
-				ctx *gin.Context,
-			) {
-				// override the context writer
-				w, _ := newRestLogMwResponseWriter(ctx.Writer)
-				ctx.Writer = w
-				// obtain and log the request content
-				req, _ := requestReader(ctx)
+			ctx *gin.Context,
+		) {
+			// retrieve the current logging rules and signals
+			rules, signals := settings.get()
+			// skip the logging of the excluded requests
+			if rules.excluded(ctx) {
+				if next != nil {
+					next(ctx)
+				}
+				return
+			}
+			// obtain the request id used to correlate the log entries
+			id := RestGetRequestID(ctx)
+			// override the context writer
+			w, _ := newRestLogMwResponseWriter(ctx.Writer)
+			ctx.Writer = w
+			// obtain and log the request content if the request
+			// is sampled to be logged
+			start := time.Now()
+			req, _ := requestReader(ctx)
+			logRequest := func() {
 				_ = logger.Signal(
-					RestLogMwRequestChannel,
-					RestLogMwRequestLevel,
-					RestLogMwRequestMessage,
+					signals.request.channel,
+					signals.request.level,
+					signals.request.message,
 					slate.LogContext{
-						"request": req,
+						RestRequestIDMwLogField: id,
+						"request":               req,
 					},
 				)
-				// execute the endpoint process and calculate the elapsed
-				// time of it
-				startTimestamp := time.Now().UnixMilli()
-				if next != nil {
-					next(ctx)
+			}
+			sampled := rules.sampled(ctx)
+			if sampled {
+				logRequest()
+			}
+			// execute the endpoint process and calculate the elapsed
+			// time of it
+			handlerStart := time.Now()
+			if next != nil {
+				next(ctx)
+			}
+			handlerElapsed := time.Since(handlerStart)
+			elapsed := time.Since(start)
+			threshold := rules.threshold(ctx)
+			slow := threshold > 0 && elapsed >= threshold
+			// discard the non-sampled requests unless it resulted
+			// in an error or took more than the slow threshold
+			if !sampled {
+				if w.Status() < http.StatusBadRequest && !slow {
+					return
 				}
-				duration := time.Now().UnixMilli() - startTimestamp
-				// obtain and log the request, response and execution duration
-				resp, _ := responseReader(ctx, w, statusCode)
+				logRequest()
+			}
+			// obtain the response logging level
+			level := signals.response.level
+			if len(rules.levels) != 0 {
+				level = rules.level(w.Status(), level)
+			}
+			// obtain and log the request, response and execution duration
+			responseStart := time.Now()
+			resp, _ := responseReader(ctx, w, statusCode)
+			_ = logger.Signal(
+				signals.response.channel,
+				level,
+				signals.response.message,
+				slate.LogContext{
+					RestRequestIDMwLogField: id,
+					"request":               req,
+					"response":              resp,
+					"duration":              elapsed.Milliseconds(),
+					"duration_ns":           elapsed.Nanoseconds(),
+				},
+			)
+			// signal the slow request with the timing breakdown
+			if slow {
 				_ = logger.Signal(
-					RestLogMwResponseChannel,
-					RestLogMwResponseLevel,
-					RestLogMwResponseMessage,
+					signals.slow.channel,
+					signals.slow.level,
+					signals.slow.message,
 					slate.LogContext{
-						"request":  req,
-						"response": resp,
-						"duration": duration,
+						RestRequestIDMwLogField: id,
+						"request":               req,
+						"response":              resp,
+						"duration":              elapsed.Milliseconds(),
+						"duration_ns":           elapsed.Nanoseconds(),
+						"threshold":             threshold.Milliseconds(),
+						"timing": slate.LogContext{
+							"request":  handlerStart.Sub(start).Nanoseconds(),
+							"handler":  handlerElapsed.Nanoseconds(),
+							"response": time.Since(responseStart).Nanoseconds(),
+						},
 					},
 				)
 			}
 		}
-	}, nil
+	}
 }
 
 // ----------------------------------------------------------------------------
@@ -508,14 +893,43 @@ func NewRestLogMwServiceRegister(
 	}
 }
 
-// Provide will add to the container a new file system adapter instance.
-func (RestLogMwServiceRegister) Provide(
+// Provide will add to the container the log middleware generators, the
+// configured request and response readers and the related services.
+func (sr RestLogMwServiceRegister) Provide(
 	container *slate.ServiceContainer,
 ) error {
 	// check container argument reference
 	if container == nil {
 		return errNilPointer("container")
 	}
-	_ = container.Add(RestLogMwContainerID, NewRestLogMwGenerator)
+	_ = container.Add(RestLogMwContainerID, NewRestLogMwConfigGenerator)
+	_ = container.Add(RestLogMwEndpointContainerID, NewRestLogMwEndpointGenerator)
+	_ = container.Add(RestLogMwRedactorContainerID, NewRestLogMwRedactor)
+	_ = container.Add(RestLogMwAllModelProvidersContainerID, sr.getModelProviders(container))
+	_ = container.Add(RestLogMwReaderFactoryContainerID, NewRestLogMwReaderFactory)
+	_ = container.Add(RestLogMwRequestReaderContainerID, func(factory *RestLogMwReaderFactory) RestLogMwRequestReader {
+		return factory.RequestReader("")
+	})
+	_ = container.Add(RestLogMwResponseReaderContainerID, func(factory *RestLogMwReaderFactory) RestLogMwResponseReader {
+		return factory.ResponseReader("")
+	})
 	return nil
 }
+
+func (RestLogMwServiceRegister) getModelProviders(
+	container *slate.ServiceContainer,
+) func() []RestLogMwModelProvider {
+	return func() []RestLogMwModelProvider {
+		// retrieve all the body model providers
+		var providers []RestLogMwModelProvider
+		entries, _ := container.Tag(RestLogMwModelProviderTag)
+		for _, entry := range entries {
+			// type check the retrieved service
+			provider, ok := entry.(RestLogMwModelProvider)
+			if ok {
+				providers = append(providers, provider)
+			}
+		}
+		return providers
+	}
+}
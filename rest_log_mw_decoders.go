@@ -0,0 +1,452 @@
+package sapi
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/happyhippyhippo/slate"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+var (
+	// RestLogMwMultipartValues defines if the multipart request field
+	// values are logged. If not, only the field names and value sizes are
+	// logged.
+	RestLogMwMultipartValues = slate.EnvBool(RestLogMwEnvID+"_MULTIPART_VALUES", false)
+)
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Request Reader Form Decorator
+// ----------------------------------------------------------------------------
+
+// NewRestLogMwRequestReaderFormDecorator will instantiate a new request
+// event context reader decorator used to parse an url encoded form request
+// body and add the parsed fields into the logging data.
+func NewRestLogMwRequestReaderFormDecorator(
+	reader RestLogMwRequestReader,
+) (RestLogMwRequestReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// return the decorated request reader method
+	return func(
+		ctx *gin.Context,
+	) (slate.LogContext, error) {
+		// check the context argument reference
+		if ctx == nil {
+			return nil, errNilPointer("ctx")
+		}
+		// read the logging request data from the context
+		data, e := reader(ctx)
+		if e != nil {
+			return nil, e
+		}
+		// try to parse the request body content if the request is an
+		// url encoded form, and store it in the data map on the
+		// bodyForm field
+		contentType := strings.ToLower(ctx.Request.Header.Get("Content-Type"))
+		if strings.HasPrefix(contentType, gin.MIMEPOSTForm) {
+			body, _ := data["body"].(string)
+			if values, e := url.ParseQuery(body); e == nil {
+				data["bodyForm"] = restLogMwFlatValues(values)
+			}
+		}
+		// return the request information
+		return data, nil
+	}, nil
+}
+
+func restLogMwFlatValues(
+	values map[string][]string,
+) slate.LogContext {
+	// try to flat single entry fields
+	result := slate.LogContext{}
+	for name, value := range values {
+		if len(value) == 1 {
+			result[name] = value[0]
+		} else {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Request Reader Multipart Decorator
+// ----------------------------------------------------------------------------
+
+// NewRestLogMwRequestReaderMultipartDecorator will instantiate a new
+// request event context reader decorator used to parse a multipart form
+// request body and add the field names and the uploaded files names and
+// sizes, but not their contents, into the logging data. The field values
+// are only logged if the RestLogMwMultipartValues flag is set, and no more
+// than RestLogMwBodyMaxSize bytes of the body are parsed. The request body
+// will still be fully available to the handler.
+func NewRestLogMwRequestReaderMultipartDecorator(
+	reader RestLogMwRequestReader,
+) (RestLogMwRequestReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// return the decorated request reader method
+	return func(
+		ctx *gin.Context,
+	) (slate.LogContext, error) {
+		// check the context argument reference
+		if ctx == nil {
+			return nil, errNilPointer("ctx")
+		}
+		// read the logging request data from the context
+		data, e := reader(ctx)
+		if e != nil {
+			return nil, e
+		}
+		// try to parse the request body content if the request is a
+		// multipart form, and store it in the data map on the
+		// bodyMultipart field
+		mediaType, params, e := mime.ParseMediaType(ctx.Request.Header.Get("Content-Type"))
+		if e == nil && mediaType == gin.MIMEMultipartPOSTForm && params["boundary"] != "" && ctx.Request.Body != nil {
+			data["bodyMultipart"] = restLogMwMultipart(ctx, params["boundary"])
+		}
+		// return the request information
+		return data, nil
+	}, nil
+}
+
+func restLogMwMultipart(
+	ctx *gin.Context,
+	boundary string,
+) slate.LogContext {
+	// obtain the request body up to the maximum parsed size
+	var raw []byte
+	if RestLogMwBodyMaxSize <= 0 {
+		raw, _ = io.ReadAll(ctx.Request.Body)
+	} else {
+		raw, _ = io.ReadAll(io.LimitReader(ctx.Request.Body, int64(RestLogMwBodyMaxSize)+1))
+	}
+	truncated := RestLogMwBodyMaxSize > 0 && len(raw) > RestLogMwBodyMaxSize
+	// restore the request body, chaining the remaining unread content
+	// if the body exceeded the maximum parsed size
+	if truncated {
+		ctx.Request.Body = &restLogMwRequestBody{
+			Reader: io.MultiReader(bytes.NewReader(raw), ctx.Request.Body),
+			Closer: ctx.Request.Body,
+		}
+		raw = raw[:RestLogMwBodyMaxSize]
+	} else {
+		ctx.Request.Body = io.NopCloser(bytes.NewBuffer(raw))
+	}
+	// iterate through all the body parts
+	var fields []slate.LogContext
+	var files []slate.LogContext
+	mr := multipart.NewReader(bytes.NewReader(raw), boundary)
+	for {
+		part, e := mr.NextPart()
+		if e != nil {
+			if !errors.Is(e, io.EOF) {
+				truncated = true
+			}
+			break
+		}
+		if part.FileName() == "" {
+			// store the field name and value size, and the value
+			// if flagged to be logged
+			field := slate.LogContext{"field": part.FormName()}
+			if RestLogMwMultipartValues {
+				value, _ := io.ReadAll(part)
+				field["value"] = string(value)
+				field["size"] = int64(len(value))
+			} else {
+				field["size"], _ = io.Copy(io.Discard, part)
+			}
+			fields = append(fields, field)
+		} else {
+			// store the file information without its content
+			size, _ := io.Copy(io.Discard, part)
+			files = append(files, slate.LogContext{
+				"field":       part.FormName(),
+				"filename":    part.FileName(),
+				"contentType": part.Header.Get("Content-Type"),
+				"size":        size,
+			})
+		}
+		_ = part.Close()
+	}
+	return slate.LogContext{
+		"fields":    fields,
+		"files":     files,
+		"truncated": truncated,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Reader Decompress Decorators
+// ----------------------------------------------------------------------------
+
+// NewRestLogMwRequestReaderDecompressDecorator will instantiate a new
+// request event context reader decorator used to decode the gzip or
+// deflate encoded request body to be logged. The request itself is left
+// untouched. This decorator should be wrapped by any body parsing
+// decorator, so they can parse the decoded content.
+func NewRestLogMwRequestReaderDecompressDecorator(
+	reader RestLogMwRequestReader,
+) (RestLogMwRequestReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// return the decorated request reader method
+	return func(
+		ctx *gin.Context,
+	) (slate.LogContext, error) {
+		// check the context argument reference
+		if ctx == nil {
+			return nil, errNilPointer("ctx")
+		}
+		// read the logging request data from the context
+		data, e := reader(ctx)
+		if e != nil {
+			return nil, e
+		}
+		// decode the logged body content
+		if body, ok := data["body"].(string); ok {
+			data["body"] = restLogMwDecompress(body, ctx.Request.Header.Get("Content-Encoding"))
+		}
+		// return the request information
+		return data, nil
+	}, nil
+}
+
+// NewRestLogMwResponseReaderDecompressDecorator will instantiate a new
+// response event context reader decorator used to decode the gzip or
+// deflate encoded response body to be logged. This decorator should be
+// wrapped by any body parsing decorator, so they can parse the decoded
+// content.
+func NewRestLogMwResponseReaderDecompressDecorator(
+	reader RestLogMwResponseReader,
+) (RestLogMwResponseReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// return the decorated response reader method
+	return func(
+		ctx *gin.Context,
+		writer gin.ResponseWriter,
+		statusCode int,
+	) (slate.LogContext, error) {
+		// check the writer argument reference
+		if writer == nil {
+			return nil, errNilPointer("writer")
+		}
+		// read the logging response data from the context
+		data, e := reader(ctx, writer, statusCode)
+		if e != nil {
+			return nil, e
+		}
+		// decode the logged body content
+		if body, ok := data["body"].(string); ok {
+			data["body"] = restLogMwDecompress(body, writer.Header().Get("Content-Encoding"))
+		}
+		// return the response information
+		return data, nil
+	}, nil
+}
+
+func restLogMwDecompress(
+	body string,
+	encoding string,
+) string {
+	// remove the truncation marker from the encoded content
+	truncated := strings.HasSuffix(body, RestLogMwBodyTruncatedMarker)
+	raw := strings.TrimSuffix(body, RestLogMwBodyTruncatedMarker)
+	// generate the decoder of the content encoding
+	var decoder io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "gzip", "x-gzip":
+		r, e := gzip.NewReader(strings.NewReader(raw))
+		if e != nil {
+			return body
+		}
+		decoder = r
+	case "deflate":
+		// the deflate encoding should be zlib wrapped, but some
+		// implementations send the raw deflate stream
+		if r, e := zlib.NewReader(strings.NewReader(raw)); e == nil {
+			decoder = r
+		} else {
+			decoder = flate.NewReader(strings.NewReader(raw))
+		}
+	default:
+		return body
+	}
+	defer func() { _ = decoder.Close() }()
+	// decode the content up to the maximum captured size
+	var decoded []byte
+	var e error
+	if RestLogMwBodyMaxSize <= 0 {
+		decoded, e = io.ReadAll(decoder)
+	} else {
+		decoded, e = io.ReadAll(io.LimitReader(decoder, int64(RestLogMwBodyMaxSize)+1))
+		if len(decoded) > RestLogMwBodyMaxSize {
+			decoded = decoded[:RestLogMwBodyMaxSize]
+			truncated = true
+		}
+	}
+	if e != nil {
+		if len(decoded) == 0 {
+			return body
+		}
+		truncated = true
+	}
+	if truncated {
+		return string(decoded) + RestLogMwBodyTruncatedMarker
+	}
+	return string(decoded)
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Response Reader Msgpack Decorator
+// ----------------------------------------------------------------------------
+
+// NewRestLogMwResponseReaderMsgpackDecorator will instantiate a new response
+// event context reader decorator used to parse the response body as a
+// msgpack content and add the parsed content into the logging data. The
+// response format is obtained from the response Content-Type header,
+// falling back to the request Accept header if the response does not
+// define it.
+func NewRestLogMwResponseReaderMsgpackDecorator(
+	reader RestLogMwResponseReader,
+	model interface{},
+) (RestLogMwResponseReader, error) {
+	return newRestLogMwResponseReaderMsgpackDecorator(reader, func() interface{} {
+		return model
+	})
+}
+
+func newRestLogMwResponseReaderMsgpackDecorator(
+	reader RestLogMwResponseReader,
+	newModel func() interface{},
+) (RestLogMwResponseReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// return the decorated response reader method
+	return func(
+		ctx *gin.Context,
+		writer gin.ResponseWriter,
+		statusCode int,
+	) (slate.LogContext, error) {
+		// check the context argument reference
+		if ctx == nil {
+			return nil, errNilPointer("ctx")
+		}
+		// check the writer argument reference
+		if writer == nil {
+			return nil, errNilPointer("writer")
+		}
+		// read the logging response data from the context
+		data, e := reader(ctx, writer, statusCode)
+		if e != nil {
+			return nil, e
+		}
+		// check if there is content in the response body logging data
+		// and try to decode it if the response is in msgpack to be logged
+		// in the bodyMsgpack field
+		if body, ok := data["body"].(string); ok {
+			contentType := getResponseContentType(ctx, writer)
+			if strings.Contains(contentType, binding.MIMEMSGPACK) || strings.Contains(contentType, binding.MIMEMSGPACK2) {
+				handle := &codec.MsgpackHandle{}
+				handle.MapType = reflect.TypeOf(map[string]interface{}{})
+				handle.RawToString = true
+				model := newModel()
+				if e = codec.NewDecoderBytes([]byte(body), handle).Decode(&model); e == nil {
+					data["bodyMsgpack"] = model
+				}
+			}
+		}
+		// return the response information
+		return data, nil
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Response Reader YAML Decorator
+// ----------------------------------------------------------------------------
+
+// NewRestLogMwResponseReaderYAMLDecorator will instantiate a new response
+// event context reader decorator used to parse the response body as a YAML
+// content and add the parsed content into the logging data. The response
+// format is obtained from the response Content-Type header, falling back
+// to the request Accept header if the response does not define it.
+func NewRestLogMwResponseReaderYAMLDecorator(
+	reader RestLogMwResponseReader,
+	model interface{},
+) (RestLogMwResponseReader, error) {
+	return newRestLogMwResponseReaderYAMLDecorator(reader, func() interface{} {
+		return model
+	})
+}
+
+func newRestLogMwResponseReaderYAMLDecorator(
+	reader RestLogMwResponseReader,
+	newModel func() interface{},
+) (RestLogMwResponseReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// return the decorated response reader method
+	return func(
+		ctx *gin.Context,
+		writer gin.ResponseWriter,
+		statusCode int,
+	) (slate.LogContext, error) {
+		// check the context argument reference
+		if ctx == nil {
+			return nil, errNilPointer("ctx")
+		}
+		// check the writer argument reference
+		if writer == nil {
+			return nil, errNilPointer("writer")
+		}
+		// read the logging response data from the context
+		data, e := reader(ctx, writer, statusCode)
+		if e != nil {
+			return nil, e
+		}
+		// check if there is content in the response body logging data
+		// and try to decode it if the response is in YAML to be logged
+		// in the bodyYaml field
+		if body, ok := data["body"].(string); ok {
+			contentType := getResponseContentType(ctx, writer)
+			if strings.Contains(contentType, "yaml") {
+				model := newModel()
+				if e = yaml.Unmarshal([]byte(body), &model); e == nil {
+					data["bodyYaml"] = model
+				}
+			}
+		}
+		// return the response information
+		return data, nil
+	}, nil
+}
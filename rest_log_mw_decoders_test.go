@@ -0,0 +1,585 @@
+package sapi
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/golang/mock/gomock"
+	"github.com/happyhippyhippo/slate"
+	"github.com/ugorji/go/codec"
+)
+
+func Test_RestLogMwRequestReaderFormDecorator(t *testing.T) {
+	t.Run("NewRestLogMwRequestReaderFormDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwRequestReaderFormDecorator(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil context", func(t *testing.T) {
+			sut, _ := NewRestLogMwRequestReaderFormDecorator(NewRestLogMwRequestReader())
+
+			if _, e := sut(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			expected := fmt.Errorf("error message")
+			sut, _ := NewRestLogMwRequestReaderFormDecorator(func(_ *gin.Context) (slate.LogContext, error) {
+				return nil, expected
+			})
+
+			if _, e := sut(&gin.Context{}); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		t.Run("non-form content-type does not add decorated field", func(t *testing.T) {
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a=1"))
+			ctx.Request.Header.Set("Content-Type", gin.MIMEJSON)
+			sut, _ := NewRestLogMwRequestReaderFormDecorator(NewRestLogMwRequestReader())
+
+			if data, _ := sut(ctx); data["bodyForm"] != nil {
+				t.Errorf("(%v) unexpected decorated field", data["bodyForm"])
+			}
+		})
+
+		t.Run("correctly add decorated field", func(t *testing.T) {
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a=1&b=2&b=3"))
+			ctx.Request.Header.Set("Content-Type", gin.MIMEPOSTForm+"; charset=utf-8")
+			sut, _ := NewRestLogMwRequestReaderFormDecorator(NewRestLogMwRequestReader())
+			expected := slate.LogContext{"a": "1", "b": []string{"2", "3"}}
+
+			if data, _ := sut(ctx); !reflect.DeepEqual(data["bodyForm"], expected) {
+				t.Errorf("(%v) when expecting (%v)", data["bodyForm"], expected)
+			}
+		})
+	})
+}
+
+func Test_RestLogMwRequestReaderMultipartDecorator(t *testing.T) {
+	body := func() (*bytes.Buffer, string) {
+		buffer := &bytes.Buffer{}
+		writer := multipart.NewWriter(buffer)
+		_ = writer.WriteField("name", "value")
+		file, _ := writer.CreateFormFile("upload", "file.bin")
+		_, _ = file.Write(bytes.Repeat([]byte{1}, 100))
+		_ = writer.Close()
+		return buffer, writer.FormDataContentType()
+	}
+
+	t.Run("NewRestLogMwRequestReaderMultipartDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwRequestReaderMultipartDecorator(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil context", func(t *testing.T) {
+			sut, _ := NewRestLogMwRequestReaderMultipartDecorator(NewRestLogMwRequestReader())
+
+			if _, e := sut(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			expected := fmt.Errorf("error message")
+			sut, _ := NewRestLogMwRequestReaderMultipartDecorator(func(_ *gin.Context) (slate.LogContext, error) {
+				return nil, expected
+			})
+
+			if _, e := sut(&gin.Context{}); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		t.Run("correctly add decorated field", func(t *testing.T) {
+			buffer, contentType := body()
+			raw := buffer.String()
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", buffer)
+			ctx.Request.Header.Set("Content-Type", contentType)
+			sut, _ := NewRestLogMwRequestReaderMultipartDecorator(NewRestLogMwRequestReader())
+			expected := slate.LogContext{
+				"fields": []slate.LogContext{{"field": "name", "size": int64(5)}},
+				"files": []slate.LogContext{{
+					"field":       "upload",
+					"filename":    "file.bin",
+					"contentType": "application/octet-stream",
+					"size":        int64(100),
+				}},
+				"truncated": false,
+			}
+
+			data, _ := sut(ctx)
+			if !reflect.DeepEqual(data["bodyMultipart"], expected) {
+				t.Errorf("(%v) when expecting (%v)", data["bodyMultipart"], expected)
+			} else if data["body"] != RestLogMwBodySkippedMarker {
+				t.Errorf("(%v) logged raw body", data["body"])
+			} else if check, _ := io.ReadAll(ctx.Request.Body); string(check) != raw {
+				t.Error("didn't restored the request body")
+			}
+		})
+
+		t.Run("log the field values if flagged", func(t *testing.T) {
+			prev := RestLogMwMultipartValues
+			RestLogMwMultipartValues = true
+			defer func() { RestLogMwMultipartValues = prev }()
+
+			buffer, contentType := body()
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", buffer)
+			ctx.Request.Header.Set("Content-Type", contentType)
+			sut, _ := NewRestLogMwRequestReaderMultipartDecorator(NewRestLogMwRequestReader())
+			expected := []slate.LogContext{{"field": "name", "value": "value", "size": int64(5)}}
+
+			data, _ := sut(ctx)
+			if check := data["bodyMultipart"].(slate.LogContext)["fields"]; !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("flag truncated bodies", func(t *testing.T) {
+			prev := RestLogMwBodyMaxSize
+			RestLogMwBodyMaxSize = 150
+			defer func() { RestLogMwBodyMaxSize = prev }()
+
+			buffer, contentType := body()
+			raw := buffer.String()
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", buffer)
+			ctx.Request.Header.Set("Content-Type", contentType)
+			sut, _ := NewRestLogMwRequestReaderMultipartDecorator(NewRestLogMwRequestReader())
+
+			data, _ := sut(ctx)
+			if check := data["bodyMultipart"].(slate.LogContext)["truncated"]; check != true {
+				t.Error("didn't flagged the parsed body as truncated")
+			} else if check, _ := io.ReadAll(ctx.Request.Body); string(check) != raw {
+				t.Error("didn't restored the request body")
+			}
+		})
+	})
+}
+
+func Test_restLogMwDecompress(t *testing.T) {
+	encode := func(encoding, content string) string {
+		buffer := &bytes.Buffer{}
+		var writer io.WriteCloser
+		switch encoding {
+		case "gzip":
+			writer = gzip.NewWriter(buffer)
+		case "deflate":
+			writer = zlib.NewWriter(buffer)
+		default:
+			writer, _ = flate.NewWriter(buffer, flate.DefaultCompression)
+		}
+		_, _ = writer.Write([]byte(content))
+		_ = writer.Close()
+		return buffer.String()
+	}
+
+	t.Run("unknown encoding", func(t *testing.T) {
+		if check := restLogMwDecompress("content", "br"); check != "content" {
+			t.Errorf("(%v) when expecting (content)", check)
+		}
+	})
+
+	t.Run("invalid content", func(t *testing.T) {
+		if check := restLogMwDecompress("content", "gzip"); check != "content" {
+			t.Errorf("(%v) when expecting (content)", check)
+		}
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		for _, encoding := range []string{"gzip", "deflate"} {
+			if check := restLogMwDecompress(encode(encoding, `{"field":"value"}`), encoding); check != `{"field":"value"}` {
+				t.Errorf("(%s) (%v) when expecting the decoded content", encoding, check)
+			}
+		}
+	})
+
+	t.Run("decode raw deflate", func(t *testing.T) {
+		if check := restLogMwDecompress(encode("flate", "content"), "deflate"); check != "content" {
+			t.Errorf("(%v) when expecting (content)", check)
+		}
+	})
+
+	t.Run("truncate decoded content", func(t *testing.T) {
+		prev := RestLogMwBodyMaxSize
+		RestLogMwBodyMaxSize = 4
+		defer func() { RestLogMwBodyMaxSize = prev }()
+
+		expected := "cont" + RestLogMwBodyTruncatedMarker
+		if check := restLogMwDecompress(encode("gzip", "content"), "gzip"); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
+	t.Run("decode truncated content", func(t *testing.T) {
+		encoded := encode("gzip", strings.Repeat("content", 100))
+		body := encoded[:len(encoded)-10] + RestLogMwBodyTruncatedMarker
+
+		if check := restLogMwDecompress(body, "gzip"); !strings.HasSuffix(check, RestLogMwBodyTruncatedMarker) || !strings.HasPrefix(check, "content") {
+			t.Errorf("(%v) unexpected decoded content", check)
+		}
+	})
+}
+
+func Test_RestLogMwReaderDecompressDecorators(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, _ = writer.Write([]byte("content"))
+	_ = writer.Close()
+	encoded := buffer.String()
+
+	t.Run("NewRestLogMwRequestReaderDecompressDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwRequestReaderDecompressDecorator(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil context", func(t *testing.T) {
+			sut, _ := NewRestLogMwRequestReaderDecompressDecorator(NewRestLogMwRequestReader())
+
+			if _, e := sut(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			expected := fmt.Errorf("error message")
+			sut, _ := NewRestLogMwRequestReaderDecompressDecorator(func(_ *gin.Context) (slate.LogContext, error) {
+				return nil, expected
+			})
+
+			if _, e := sut(&gin.Context{}); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		t.Run("decode the logged body", func(t *testing.T) {
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(encoded))
+			ctx.Request.Header.Set("Content-Encoding", "gzip")
+			sut, _ := NewRestLogMwRequestReaderDecompressDecorator(NewRestLogMwRequestReader())
+
+			if data, _ := sut(ctx); data["body"] != "content" {
+				t.Errorf("(%v) when expecting (content)", data["body"])
+			} else if check, _ := io.ReadAll(ctx.Request.Body); string(check) != encoded {
+				t.Error("changed the request body")
+			}
+		})
+	})
+
+	t.Run("NewRestLogMwResponseReaderDecompressDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwResponseReaderDecompressDecorator(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil writer", func(t *testing.T) {
+			sut, _ := NewRestLogMwResponseReaderDecompressDecorator(NewRestLogMwResponseReader())
+
+			if _, e := sut(&gin.Context{}, nil, 200); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			expected := fmt.Errorf("error message")
+			sut, _ := NewRestLogMwResponseReaderDecompressDecorator(func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return nil, expected
+			})
+			gin.SetMode(gin.ReleaseMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+			if _, e := sut(ctx, ctx.Writer, 200); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		t.Run("decode the logged body", func(t *testing.T) {
+			gin.SetMode(gin.ReleaseMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			w, _ := newRestLogMwResponseWriter(ctx.Writer)
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(encoded))
+			sut, _ := NewRestLogMwResponseReaderDecompressDecorator(NewRestLogMwResponseReader())
+
+			if data, _ := sut(ctx, w, 200); data["body"] != "content" {
+				t.Errorf("(%v) when expecting (content)", data["body"])
+			}
+		})
+	})
+}
+
+func Test_RestLogMwResponseReaderMsgpackDecorator(t *testing.T) {
+	t.Run("NewRestLogMwResponseReaderMsgpackDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwResponseReaderMsgpackDecorator(nil, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil context", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderMsgpackDecorator(reader, nil)
+
+			if _, e := decorator(nil, NewMockResponseWriter(ctrl), 0); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil writer", func(t *testing.T) {
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderMsgpackDecorator(reader, nil)
+
+			if _, e := decorator(&gin.Context{}, nil, 0); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expected := fmt.Errorf("error message")
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return nil, expected
+			}
+			decorator, _ := NewRestLogMwResponseReaderMsgpackDecorator(reader, nil)
+
+			if _, e := decorator(&gin.Context{}, NewMockResponseWriter(ctrl), 0); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		raw := []byte{}
+		_ = codec.NewEncoderBytes(&raw, &codec.MsgpackHandle{}).Encode(map[string]interface{}{"field": "value"})
+		body := string(raw)
+		check := func(t *testing.T, contentType string) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {contentType}}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{"body": body}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderMsgpackDecorator(reader, nil)
+
+			expected := map[string]interface{}{"field": "value"}
+			if data, e := decorator(ctx, writer, 0); e != nil {
+				t.Errorf("returned the unexpected (%v) error", e)
+			} else if value := data["bodyMsgpack"]; !reflect.DeepEqual(value, expected) {
+				t.Errorf("(%v) when expecting (%v)", value, expected)
+			}
+		}
+
+		t.Run("decode the body of the "+binding.MIMEMSGPACK+" content type", func(t *testing.T) {
+			check(t, binding.MIMEMSGPACK)
+		})
+
+		t.Run("decode the body of the "+binding.MIMEMSGPACK2+" content type", func(t *testing.T) {
+			check(t, binding.MIMEMSGPACK2)
+		})
+
+		t.Run("decode the body of the accepted type if the response content type is not set", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.Header.Set("Accept", binding.MIMEMSGPACK)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{"body": body}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderMsgpackDecorator(reader, nil)
+
+			if data, e := decorator(ctx, writer, 0); e != nil {
+				t.Errorf("returned the unexpected (%v) error", e)
+			} else if _, ok := data["bodyMsgpack"]; !ok {
+				t.Error("didn't added the bodyMsgpack field")
+			}
+		})
+
+		t.Run("don't decode the body of other content types", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.Header.Set("Accept", binding.MIMEMSGPACK)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {gin.MIMEJSON}}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{"body": body}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderMsgpackDecorator(reader, nil)
+
+			if data, e := decorator(ctx, writer, 0); e != nil {
+				t.Errorf("returned the unexpected (%v) error", e)
+			} else if value, ok := data["bodyMsgpack"]; ok {
+				t.Errorf("added the (%v) bodyMsgpack field", value)
+			}
+		})
+	})
+}
+
+func Test_RestLogMwResponseReaderYAMLDecorator(t *testing.T) {
+	t.Run("NewRestLogMwResponseReaderYAMLDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwResponseReaderYAMLDecorator(nil, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil context", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderYAMLDecorator(reader, nil)
+
+			if _, e := decorator(nil, NewMockResponseWriter(ctrl), 0); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil writer", func(t *testing.T) {
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderYAMLDecorator(reader, nil)
+
+			if _, e := decorator(&gin.Context{}, nil, 0); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expected := fmt.Errorf("error message")
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return nil, expected
+			}
+			decorator, _ := NewRestLogMwResponseReaderYAMLDecorator(reader, nil)
+
+			if _, e := decorator(&gin.Context{}, NewMockResponseWriter(ctrl), 0); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		body := "field: value\n"
+		check := func(t *testing.T, contentType string) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {contentType}}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{"body": body}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderYAMLDecorator(reader, nil)
+
+			expected := map[string]interface{}{"field": "value"}
+			if data, e := decorator(ctx, writer, 0); e != nil {
+				t.Errorf("returned the unexpected (%v) error", e)
+			} else if value := data["bodyYaml"]; !reflect.DeepEqual(value, expected) {
+				t.Errorf("(%v) when expecting (%v)", value, expected)
+			}
+		}
+
+		t.Run("decode the body of the "+gin.MIMEYAML+" content type", func(t *testing.T) {
+			check(t, gin.MIMEYAML)
+		})
+
+		t.Run("decode the body of the "+"application/yaml"+" content type", func(t *testing.T) {
+			check(t, "application/yaml")
+		})
+
+		t.Run("decode the body of the "+"text/yaml; charset=utf-8"+" content type", func(t *testing.T) {
+			check(t, "text/yaml; charset=utf-8")
+		})
+
+		t.Run("decode the body of the accepted type if the response content type is not set", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.Header.Set("Accept", gin.MIMEYAML)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{"body": body}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderYAMLDecorator(reader, nil)
+
+			if data, e := decorator(ctx, writer, 0); e != nil {
+				t.Errorf("returned the unexpected (%v) error", e)
+			} else if _, ok := data["bodyYaml"]; !ok {
+				t.Error("didn't added the bodyYaml field")
+			}
+		})
+
+		t.Run("don't decode the body of other content types", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.Header.Set("Accept", gin.MIMEYAML)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {gin.MIMEJSON}}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return slate.LogContext{"body": body}, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderYAMLDecorator(reader, nil)
+
+			if data, e := decorator(ctx, writer, 0); e != nil {
+				t.Errorf("returned the unexpected (%v) error", e)
+			} else if value, ok := data["bodyYaml"]; ok {
+				t.Errorf("added the (%v) bodyYaml field", value)
+			}
+		})
+	})
+}
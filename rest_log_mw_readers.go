@@ -0,0 +1,512 @@
+package sapi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// RestLogMwEndpointContainerID defines the id used to register the
+	// endpoint aware log middleware generator in the application container.
+	RestLogMwEndpointContainerID = RestLogMwContainerID + ".endpoint"
+
+	// RestLogMwReaderFactoryContainerID defines the id used to register
+	// the log middleware readers factory in the application container.
+	RestLogMwReaderFactoryContainerID = RestLogMwContainerID + ".readers"
+
+	// RestLogMwRequestReaderContainerID defines the id used to register
+	// the default log middleware request reader in the application
+	// container.
+	RestLogMwRequestReaderContainerID = RestLogMwReaderFactoryContainerID + ".request"
+
+	// RestLogMwResponseReaderContainerID defines the id used to register
+	// the default log middleware response reader in the application
+	// container.
+	RestLogMwResponseReaderContainerID = RestLogMwReaderFactoryContainerID + ".response"
+
+	// RestLogMwModelProviderTag defines the tag to be assigned to all the
+	// log middleware body model providers registered in the application
+	// container.
+	RestLogMwModelProviderTag = RestLogMwContainerID + ".models"
+
+	// RestLogMwAllModelProvidersContainerID defines the id to be used as
+	// the container registration id of the list of all body model providers.
+	RestLogMwAllModelProvidersContainerID = RestLogMwModelProviderTag + ".all"
+)
+
+var (
+	// RestLogMwConfigPathReaders defines the config path used to store the
+	// decorators applied to the log middleware request and response
+	// readers, globally and per endpoint.
+	RestLogMwConfigPathReaders = slate.EnvString(RestLogMwEnvID+"_CONFIG_PATH_READERS", "slate.api.rest.log.readers")
+
+	// RestLogMwRequestDecorators defines the default list of decorators
+	// applied to the request reader if no configuration is given.
+	RestLogMwRequestDecorators = slate.EnvList(RestLogMwEnvID+"_REQUEST_DECORATORS", []string{"decompress", "json", "xml", "form", "redact"})
+
+	// RestLogMwResponseDecorators defines the default list of decorators
+	// applied to the response reader if no configuration is given.
+	RestLogMwResponseDecorators = slate.EnvList(RestLogMwEnvID+"_RESPONSE_DECORATORS", []string{"decompress", "json", "xml", "redact"})
+
+	// RestLogMwLogReadersErrorMessage defines the logging message used when
+	// the log middleware readers configuration is invalid.
+	RestLogMwLogReadersErrorMessage = slate.EnvString(RestLogMwEnvID+"_LOG_READERS_ERROR_MESSAGE", "Invalid log middleware readers")
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrUnknownRestLogMwDecorator defines an error that denotes that a
+	// configured log middleware reader decorator is not known.
+	ErrUnknownRestLogMwDecorator = fmt.Errorf("unknown log middleware decorator")
+
+	// ErrUnknownRestLogMwModel defines an error that denotes that a
+	// configured log middleware body model was not registered.
+	ErrUnknownRestLogMwModel = fmt.Errorf("unknown log middleware model")
+)
+
+func errUnknownRestLogMwDecorator(
+	name string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrUnknownRestLogMwDecorator, name, ctx...)
+}
+
+func errUnknownRestLogMwModel(
+	name string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrUnknownRestLogMwModel, name, ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Model Provider
+// ----------------------------------------------------------------------------
+
+// RestLogMwModelProvider defines an interface to an instance that is able
+// to supply named body model factories, used by the configured body
+// parsing decorators to decode the request and response bodies.
+type RestLogMwModelProvider interface {
+	Models() map[string]func() interface{}
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Reader Factory
+// ----------------------------------------------------------------------------
+
+var restLogMwRequestDecoratorNames = map[string]bool{
+	"json":       true,
+	"xml":        true,
+	"form":       true,
+	"multipart":  true,
+	"decompress": true,
+	"redact":     true,
+}
+
+var restLogMwResponseDecoratorNames = map[string]bool{
+	"json":       true,
+	"xml":        true,
+	"msgpack":    true,
+	"yaml":       true,
+	"decompress": true,
+	"redact":     true,
+}
+
+type restLogMwDecorator struct {
+	name  string
+	model string
+}
+
+type restLogMwDecorators struct {
+	request  []restLogMwDecorator
+	response []restLogMwDecorator
+}
+
+type restLogMwReaders struct {
+	restLogMwDecorators
+	endpoints map[string]*restLogMwDecorators
+}
+
+// RestLogMwReaderFactory defines an instance used to generate the log
+// middleware request and response readers decorated as defined in the
+// configuration. The decorators and their body models can be defined
+// globally and overridden per endpoint.
+type RestLogMwReaderFactory struct {
+	mutex      sync.Locker
+	redactor   *RestLogMwRedactor
+	models     map[string]func() interface{}
+	requests   map[string]RestLogMwRequestReader
+	responses  map[string]RestLogMwResponseReader
+	bodyAlways bool
+}
+
+// NewRestLogMwReaderFactory will instantiate a new log middleware readers
+// factory with the decorators defined in the configuration.
+func NewRestLogMwReaderFactory(
+	config *slate.Config,
+	logger *slate.Log,
+	redactor *RestLogMwRedactor,
+	providers []RestLogMwModelProvider,
+) (*RestLogMwReaderFactory, error) {
+	// check the config argument reference
+	if config == nil {
+		return nil, errNilPointer("config")
+	}
+	// check the logger argument reference
+	if logger == nil {
+		return nil, errNilPointer("logger")
+	}
+	// check the redactor argument reference
+	if redactor == nil {
+		return nil, errNilPointer("redactor")
+	}
+	// collect the registered body models
+	factory := &RestLogMwReaderFactory{
+		mutex:    &sync.Mutex{},
+		redactor: redactor,
+		models:   map[string]func() interface{}{},
+	}
+	for _, provider := range providers {
+		for name, model := range provider.Models() {
+			factory.models[name] = model
+		}
+	}
+	// retrieve the response body logging flag from the configuration
+	var e error
+	factory.bodyAlways, e = config.Bool(RestLogMwConfigPathResponseBodyAlways, RestLogMwResponseBodyAlways)
+	if e != nil {
+		_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogReadersErrorMessage, slate.LogContext{"error": e})
+		return nil, e
+	}
+	// retrieve the readers decorators from the configuration and build
+	// the decorated readers of every configured endpoint
+	partial, e := config.Partial(RestLogMwConfigPathReaders, slate.ConfigPartial{})
+	var readers *restLogMwReaders
+	if e == nil {
+		readers, e = factory.parse(partial)
+	}
+	if e != nil {
+		_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogReadersErrorMessage, slate.LogContext{"error": e})
+		return nil, e
+	}
+	factory.requests, factory.responses = factory.build(readers)
+	// add a config observer for the readers decorators
+	if config.Has(RestLogMwConfigPathReaders) {
+		_ = config.AddObserver(RestLogMwConfigPathReaders, func(_ interface{}, new interface{}) {
+			partial, ok := new.(slate.ConfigPartial)
+			if !ok {
+				_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogReadersErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			readers, e := factory.parse(partial)
+			if e != nil {
+				_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogReadersErrorMessage, slate.LogContext{"error": e})
+				return
+			}
+			requests, responses := factory.build(readers)
+			factory.mutex.Lock()
+			factory.requests, factory.responses = requests, responses
+			factory.mutex.Unlock()
+		})
+	}
+	// add a config observer for the response body logging flag
+	if config.Has(RestLogMwConfigPathResponseBodyAlways) {
+		_ = config.AddObserver(RestLogMwConfigPathResponseBodyAlways, func(_ interface{}, new interface{}) {
+			flag, ok := new.(bool)
+			if !ok {
+				_ = logger.Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogReadersErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			factory.mutex.Lock()
+			factory.bodyAlways = flag
+			factory.mutex.Unlock()
+		})
+	}
+	return factory, nil
+}
+
+// RequestReader will retrieve a request reader decorated as configured
+// for the given endpoint. An empty endpoint will use the global
+// decorators. The reader follows any later configuration change.
+func (f *RestLogMwReaderFactory) RequestReader(
+	endpoint string,
+) RestLogMwRequestReader {
+	return func(
+		ctx *gin.Context,
+	) (slate.LogContext, error) {
+		// retrieve the current endpoint decorated reader
+		f.mutex.Lock()
+		reader, ok := f.requests[endpoint]
+		if !ok {
+			reader = f.requests[""]
+		}
+		f.mutex.Unlock()
+		return reader(ctx)
+	}
+}
+
+// ResponseReader will retrieve a response reader decorated as configured
+// for the given endpoint. An empty endpoint will use the global
+// decorators. The reader follows any later configuration change.
+func (f *RestLogMwReaderFactory) ResponseReader(
+	endpoint string,
+) RestLogMwResponseReader {
+	return func(
+		ctx *gin.Context,
+		writer gin.ResponseWriter,
+		statusCode int,
+	) (slate.LogContext, error) {
+		// retrieve the current endpoint decorated reader
+		f.mutex.Lock()
+		reader, ok := f.responses[endpoint]
+		if !ok {
+			reader = f.responses[""]
+		}
+		f.mutex.Unlock()
+		return reader(ctx, writer, statusCode)
+	}
+}
+
+func (f *RestLogMwReaderFactory) build(
+	readers *restLogMwReaders,
+) (map[string]RestLogMwRequestReader, map[string]RestLogMwResponseReader) {
+	// decorate the global readers and the endpoint overridden ones
+	requests := map[string]RestLogMwRequestReader{"": f.request(readers.request)}
+	responses := map[string]RestLogMwResponseReader{"": f.response(readers.response)}
+	for id, endpoint := range readers.endpoints {
+		if endpoint.request != nil {
+			requests[id] = f.request(endpoint.request)
+		}
+		if endpoint.response != nil {
+			responses[id] = f.response(endpoint.response)
+		}
+	}
+	return requests, responses
+}
+
+func (f *RestLogMwReaderFactory) request(
+	decorators []restLogMwDecorator,
+) RestLogMwRequestReader {
+	// decorate the base reader, with body models instantiated on every
+	// request, so the decoded models aren't shared between requests
+	reader := NewRestLogMwRequestReader()
+	for _, d := range decorators {
+		switch d.name {
+		case "json":
+			reader, _ = newRestLogMwRequestReaderJSONDecorator(reader, f.model(d.model))
+		case "xml":
+			reader, _ = newRestLogMwRequestReaderXMLDecorator(reader, f.model(d.model))
+		case "form":
+			reader, _ = NewRestLogMwRequestReaderFormDecorator(reader)
+		case "multipart":
+			reader, _ = NewRestLogMwRequestReaderMultipartDecorator(reader)
+		case "decompress":
+			reader, _ = NewRestLogMwRequestReaderDecompressDecorator(reader)
+		case "redact":
+			reader, _ = NewRestLogMwRequestReaderRedactDecorator(reader, f.redactor)
+		}
+	}
+	return reader
+}
+
+func (f *RestLogMwReaderFactory) response(
+	decorators []restLogMwDecorator,
+) RestLogMwResponseReader {
+	// decorate the base reader, with body models instantiated on every
+	// response, so the decoded models aren't shared between requests
+	reader := newRestLogMwResponseReader(f.responseBodyAlways)
+	for _, d := range decorators {
+		switch d.name {
+		case "json":
+			reader, _ = newRestLogMwResponseReaderJSONDecorator(reader, f.model(d.model))
+		case "xml":
+			reader, _ = newRestLogMwResponseReaderXMLDecorator(reader, f.model(d.model))
+		case "msgpack":
+			reader, _ = newRestLogMwResponseReaderMsgpackDecorator(reader, f.model(d.model))
+		case "yaml":
+			reader, _ = newRestLogMwResponseReaderYAMLDecorator(reader, f.model(d.model))
+		case "decompress":
+			reader, _ = NewRestLogMwResponseReaderDecompressDecorator(reader)
+		case "redact":
+			reader, _ = NewRestLogMwResponseReaderRedactDecorator(reader, f.redactor)
+		}
+	}
+	return reader
+}
+
+func (f *RestLogMwReaderFactory) responseBodyAlways() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.bodyAlways
+}
+
+func (f *RestLogMwReaderFactory) model(
+	name string,
+) func() interface{} {
+	if model, ok := f.models[name]; ok {
+		return model
+	}
+	return func() interface{} {
+		return nil
+	}
+}
+
+func (f *RestLogMwReaderFactory) parse(
+	partial slate.ConfigPartial,
+) (*restLogMwReaders, error) {
+	readers := &restLogMwReaders{endpoints: map[string]*restLogMwDecorators{}}
+	// parse the global decorators, falling back to the default lists
+	var e error
+	if readers.request, e = f.decorators(partial, "request", restLogMwRequestDecoratorNames); e != nil {
+		return nil, e
+	}
+	if readers.request == nil {
+		readers.request = restLogMwDefaultDecorators(RestLogMwRequestDecorators)
+	}
+	if readers.response, e = f.decorators(partial, "response", restLogMwResponseDecoratorNames); e != nil {
+		return nil, e
+	}
+	if readers.response == nil {
+		readers.response = restLogMwDefaultDecorators(RestLogMwResponseDecorators)
+	}
+	// parse the endpoint decorator overrides
+	endpoints, e := partial.Partial("endpoints", slate.ConfigPartial{})
+	if e != nil {
+		return nil, e
+	}
+	for key, value := range endpoints {
+		id, ok := key.(string)
+		if !ok {
+			return nil, errConversion(key, "string")
+		}
+		p, ok := value.(slate.ConfigPartial)
+		if !ok {
+			return nil, errConversion(value, "slate.ConfigPartial")
+		}
+		endpoint := &restLogMwDecorators{}
+		if endpoint.request, e = f.decorators(p, "request", restLogMwRequestDecoratorNames); e != nil {
+			return nil, e
+		}
+		if endpoint.response, e = f.decorators(p, "response", restLogMwResponseDecoratorNames); e != nil {
+			return nil, e
+		}
+		readers.endpoints[id] = endpoint
+	}
+	return readers, nil
+}
+
+func (f *RestLogMwReaderFactory) decorators(
+	partial slate.ConfigPartial,
+	field string,
+	names map[string]bool,
+) ([]restLogMwDecorator, error) {
+	// a missing list will be reported as a nil list, so it can
+	// be replaced by the inherited decorators
+	if !partial.Has(field) {
+		return nil, nil
+	}
+	list, e := partial.List(field)
+	if e != nil {
+		return nil, e
+	}
+	decorators := []restLogMwDecorator{}
+	for _, entry := range list {
+		// a decorator can be defined by its name or by a
+		// partial with its name and body model
+		var d restLogMwDecorator
+		switch v := entry.(type) {
+		case string:
+			d.name = v
+		case slate.ConfigPartial:
+			dc := struct {
+				Type  string
+				Model string
+			}{}
+			if _, e := v.Populate("", &dc); e != nil {
+				return nil, e
+			}
+			d.name, d.model = dc.Type, dc.Model
+		default:
+			return nil, errConversion(entry, "log middleware decorator")
+		}
+		d.name = strings.ToLower(d.name)
+		if !names[d.name] {
+			return nil, errUnknownRestLogMwDecorator(d.name)
+		}
+		if _, ok := f.models[d.model]; d.model != "" && !ok {
+			return nil, errUnknownRestLogMwModel(d.model)
+		}
+		decorators = append(decorators, d)
+	}
+	return decorators, nil
+}
+
+func restLogMwDefaultDecorators(
+	names []string,
+) []restLogMwDecorator {
+	var decorators []restLogMwDecorator
+	for _, name := range names {
+		decorators = append(decorators, restLogMwDecorator{name: strings.ToLower(name)})
+	}
+	return decorators
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Endpoint Generator
+// ----------------------------------------------------------------------------
+
+// RestLogMwEndpointGenerator defines the function used to generate the log
+// middleware for an endpoint, with the endpoint configured readers and the
+// given expected status code.
+type RestLogMwEndpointGenerator func(endpoint string, statusCode int) RestMiddleware
+
+// NewRestLogMwEndpointGenerator instantiates a new endpoint aware log
+// middleware generator. The generated middlewares behave as the ones
+// generated by the NewRestLogMwConfigGenerator function, but the request and
+// response readers are decorated as configured for the endpoint.
+func NewRestLogMwEndpointGenerator(
+	config *slate.Config,
+	logger *slate.Log,
+	factory *RestLogMwReaderFactory,
+) (RestLogMwEndpointGenerator, error) {
+	// check config argument reference
+	if config == nil {
+		return nil, errNilPointer("config")
+	}
+	// check logger argument reference
+	if logger == nil {
+		return nil, errNilPointer("logger")
+	}
+	// check factory argument reference
+	if factory == nil {
+		return nil, errNilPointer("factory")
+	}
+	// retrieve the logging rules and signals from the configuration
+	settings, e := newRestLogMwSettings(config, logger)
+	if e != nil {
+		return nil, e
+	}
+	// return the middleware generator function
+	return func(
+		endpoint string,
+		statusCode int,
+	) RestMiddleware {
+		return restLogMwMiddleware(
+			logger,
+			settings,
+			factory.RequestReader(endpoint),
+			factory.ResponseReader(endpoint),
+			statusCode,
+		)
+	}, nil
+}
@@ -0,0 +1,447 @@
+package sapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/happyhippyhippo/slate"
+)
+
+type restLogMwReadersTestModel struct {
+	Field string `json:"field"`
+}
+
+type restLogMwReadersTestProvider struct{}
+
+func (restLogMwReadersTestProvider) Models() map[string]func() interface{} {
+	return map[string]func() interface{}{
+		"model": func() interface{} { return &restLogMwReadersTestModel{} },
+	}
+}
+
+func restLogMwReadersTestConfig(
+	ctrl *gomock.Controller,
+	readers interface{},
+) *slate.Config {
+	partial := slate.ConfigPartial{}
+	if readers != nil {
+		_, _ = partial.Set("slate.api.rest.log.readers", readers)
+	}
+	supplier := NewMockConfigSupplier(ctrl)
+	supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+	config := slate.NewConfig()
+	_ = config.AddSupplier("id", 0, supplier)
+	return config
+}
+
+func restLogMwReadersTestContext() *gin.Context {
+	gin.SetMode(gin.ReleaseMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"field":"value"}`))
+	ctx.Request.Header.Set("Content-Type", gin.MIMEJSON)
+	ctx.Request.Header.Set("Authorization", "secret")
+	return ctx
+}
+
+func Test_RestLogMwReaderFactory(t *testing.T) {
+	t.Run("NewRestLogMwReaderFactory", func(t *testing.T) {
+		t.Run("nil config", func(t *testing.T) {
+			if _, e := NewRestLogMwReaderFactory(nil, slate.NewLog(), &RestLogMwRedactor{}, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil logger", func(t *testing.T) {
+			if _, e := NewRestLogMwReaderFactory(slate.NewConfig(), nil, &RestLogMwRedactor{}, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil redactor", func(t *testing.T) {
+			if _, e := NewRestLogMwReaderFactory(slate.NewConfig(), slate.NewLog(), nil, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("invalid readers configuration", func(t *testing.T) {
+			scenarios := []struct {
+				name     string
+				readers  interface{}
+				expected error
+			}{
+				{"invalid readers", "string", slate.ErrConversion},
+				{"invalid request list", slate.ConfigPartial{"request": "string"}, slate.ErrConversion},
+				{"invalid decorator entry", slate.ConfigPartial{"request": []interface{}{123}}, slate.ErrConversion},
+				{"invalid decorator partial", slate.ConfigPartial{"request": []interface{}{slate.ConfigPartial{"type": 123}}}, slate.ErrConversion},
+				{"unknown request decorator", slate.ConfigPartial{"request": []interface{}{"msgpack"}}, ErrUnknownRestLogMwDecorator},
+				{"unknown response decorator", slate.ConfigPartial{"response": []interface{}{"form"}}, ErrUnknownRestLogMwDecorator},
+				{"unknown model", slate.ConfigPartial{"response": []interface{}{slate.ConfigPartial{"type": "json", "model": "unknown"}}}, ErrUnknownRestLogMwModel},
+				{"invalid endpoints", slate.ConfigPartial{"endpoints": "string"}, slate.ErrConversion},
+				{"invalid endpoint", slate.ConfigPartial{"endpoints": slate.ConfigPartial{"id": "string"}}, slate.ErrConversion},
+				{"invalid endpoint request", slate.ConfigPartial{"endpoints": slate.ConfigPartial{"id": slate.ConfigPartial{"request": []interface{}{"unknown"}}}}, ErrUnknownRestLogMwDecorator},
+				{"invalid endpoint response", slate.ConfigPartial{"endpoints": slate.ConfigPartial{"id": slate.ConfigPartial{"response": []interface{}{"unknown"}}}}, ErrUnknownRestLogMwDecorator},
+			}
+
+			for _, s := range scenarios {
+				ctrl := gomock.NewController(t)
+
+				logWriter := NewMockLogWriter(ctrl)
+				logWriter.EXPECT().Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogReadersErrorMessage, gomock.Any()).Times(1)
+				logger := slate.NewLog()
+				_ = logger.AddWriter("id", logWriter)
+
+				sut, e := NewRestLogMwReaderFactory(restLogMwReadersTestConfig(ctrl, s.readers), logger, &RestLogMwRedactor{}, nil)
+				switch {
+				case sut != nil:
+					t.Errorf("(%s) returned an unexpected valid reference", s.name)
+				case !errors.Is(e, s.expected):
+					t.Errorf("(%s) (%v) when expecting (%v)", s.name, e, s.expected)
+				}
+
+				ctrl.Finish()
+			}
+		})
+
+		t.Run("invalid response body flag configuration", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.response.body_always", "string")
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).Times(1)
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogReadersErrorMessage, gomock.Any()).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			sut, e := NewRestLogMwReaderFactory(config, logger, &RestLogMwRedactor{}, nil)
+			switch {
+			case sut != nil:
+				t.Error("returned an unexpected valid reference")
+			case !errors.Is(e, slate.ErrConversion):
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+	})
+
+	t.Run("RequestReader", func(t *testing.T) {
+		t.Run("apply the default decorators", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwReadersTestConfig(ctrl, nil)
+			redactor, _ := NewRestLogMwRedactor(config)
+			sut, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, nil)
+
+			data, e := sut.RequestReader("")(restLogMwReadersTestContext())
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case !reflect.DeepEqual(data["bodyJson"], map[string]interface{}{"field": "value"}):
+				t.Errorf("(%v) unexpected bodyJson field", data["bodyJson"])
+			case data["headers"].(slate.LogContext)["Authorization"] != RestLogMwRedactMask:
+				t.Errorf("didn't redacted the authorization header : %v", data["headers"])
+			}
+		})
+
+		t.Run("apply the endpoint decorators with the registered model", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			readers := slate.ConfigPartial{
+				"request": []interface{}{"redact"},
+				"endpoints": slate.ConfigPartial{
+					"endpoint": slate.ConfigPartial{
+						"request": []interface{}{slate.ConfigPartial{"type": "json", "model": "model"}},
+					},
+				},
+			}
+			config := restLogMwReadersTestConfig(ctrl, readers)
+			redactor, _ := NewRestLogMwRedactor(config)
+			sut, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, []RestLogMwModelProvider{restLogMwReadersTestProvider{}})
+
+			data, _ := sut.RequestReader("")(restLogMwReadersTestContext())
+			if _, ok := data["bodyJson"]; ok {
+				t.Error("applied the json decorator to the global reader")
+			}
+
+			data, e := sut.RequestReader("endpoint")(restLogMwReadersTestContext())
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case !reflect.DeepEqual(data["bodyJson"], &restLogMwReadersTestModel{Field: "value"}):
+				t.Errorf("(%v) unexpected bodyJson field", data["bodyJson"])
+			case data["headers"].(slate.LogContext)["Authorization"] != "secret":
+				t.Errorf("applied the global redact decorator : %v", data["headers"])
+			}
+		})
+
+		t.Run("use the updated decorators", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			newPartial := slate.ConfigPartial{}
+			_, _ = newPartial.Set("slate.api.rest.log.readers", slate.ConfigPartial{"request": []interface{}{"json"}})
+			newSupplier := NewMockConfigSupplier(ctrl)
+			newSupplier.EXPECT().Get("").Return(newPartial, nil).Times(1)
+			config := restLogMwReadersTestConfig(ctrl, slate.ConfigPartial{"request": []interface{}{}})
+			redactor, _ := NewRestLogMwRedactor(config)
+			sut, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, nil)
+			reader := sut.RequestReader("")
+
+			if data, _ := reader(restLogMwReadersTestContext()); data["bodyJson"] != nil {
+				t.Errorf("(%v) unexpected bodyJson field", data["bodyJson"])
+			}
+			_ = config.AddSupplier("id2", 1, newSupplier)
+			if data, _ := reader(restLogMwReadersTestContext()); data["bodyJson"] == nil {
+				t.Error("didn't applied the updated decorators")
+			}
+		})
+		t.Run("build the endpoint readers once", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			readers := slate.ConfigPartial{
+				"endpoints": slate.ConfigPartial{
+					"endpoint": slate.ConfigPartial{"request": []interface{}{"json"}},
+				},
+			}
+			newPartial := slate.ConfigPartial{}
+			_, _ = newPartial.Set("slate.api.rest.log.readers", slate.ConfigPartial{"request": []interface{}{"json"}})
+			newSupplier := NewMockConfigSupplier(ctrl)
+			newSupplier.EXPECT().Get("").Return(newPartial, nil).Times(1)
+			config := restLogMwReadersTestConfig(ctrl, readers)
+			redactor, _ := NewRestLogMwRedactor(config)
+			sut, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, nil)
+			built := reflect.ValueOf(sut.requests).Pointer()
+
+			_, _ = sut.RequestReader("endpoint")(restLogMwReadersTestContext())
+			_, _ = sut.RequestReader("endpoint")(restLogMwReadersTestContext())
+			if len(sut.requests) != 2 || reflect.ValueOf(sut.requests).Pointer() != built {
+				t.Error("rebuilt the endpoint reader")
+			}
+			_ = config.AddSupplier("id2", 1, newSupplier)
+			if reflect.ValueOf(sut.requests).Pointer() == built {
+				t.Error("didn't rebuilt the readers with the updated decorators")
+			}
+		})
+
+		t.Run("decode concurrent requests into distinct models", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			readers := slate.ConfigPartial{"request": []interface{}{slate.ConfigPartial{"type": "json", "model": "model"}}}
+			config := restLogMwReadersTestConfig(ctrl, readers)
+			redactor, _ := NewRestLogMwRedactor(config)
+			sut, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, []RestLogMwModelProvider{restLogMwReadersTestProvider{}})
+			reader := sut.RequestReader("")
+
+			models := make(chan interface{}, 10)
+			wg := sync.WaitGroup{}
+			for i := 0; i < cap(models); i++ {
+				wg.Add(1)
+				go func(ctx *gin.Context) {
+					defer wg.Done()
+					data, _ := reader(ctx)
+					models <- data["bodyJson"]
+				}(restLogMwReadersTestContext())
+			}
+			wg.Wait()
+			close(models)
+
+			seen := map[interface{}]bool{}
+			for model := range models {
+				if seen[model] {
+					t.Errorf("(%v) shared decoded model", model)
+				}
+				seen[model] = true
+			}
+		})
+	})
+
+	t.Run("ResponseReader", func(t *testing.T) {
+		t.Run("apply the endpoint decorators", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			readers := slate.ConfigPartial{
+				"endpoints": slate.ConfigPartial{
+					"endpoint": slate.ConfigPartial{"response": []interface{}{"yaml"}},
+				},
+			}
+			config := restLogMwReadersTestConfig(ctrl, readers)
+			redactor, _ := NewRestLogMwRedactor(config)
+			sut, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, nil)
+
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Status().Return(http.StatusOK).AnyTimes()
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {gin.MIMEYAML}}).AnyTimes()
+			writer.EXPECT().Body().Return([]byte("field: value")).AnyTimes()
+
+			data, e := sut.ResponseReader("endpoint")(restLogMwReadersTestContext(), writer, http.StatusCreated)
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case !reflect.DeepEqual(data["bodyYaml"], map[string]interface{}{"field": "value"}):
+				t.Errorf("(%v) unexpected bodyYaml field", data["bodyYaml"])
+			}
+		})
+
+		t.Run("use the configured response body flag", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.response.body_always", false)
+			newPartial := slate.ConfigPartial{}
+			_, _ = newPartial.Set("slate.api.rest.log.response.body_always", true)
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+			newSupplier := NewMockConfigSupplier(ctrl)
+			newSupplier.EXPECT().Get("").Return(newPartial, nil).Times(1)
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id1", 0, supplier)
+			redactor, _ := NewRestLogMwRedactor(config)
+			sut, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, nil)
+			reader := sut.ResponseReader("")
+
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Status().Return(http.StatusOK).AnyTimes()
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {gin.MIMEPlain}}).AnyTimes()
+			writer.EXPECT().Body().Return([]byte("body")).AnyTimes()
+
+			if data, _ := reader(restLogMwReadersTestContext(), writer, http.StatusOK); data["body"] != nil {
+				t.Errorf("(%v) unexpected body field", data["body"])
+			}
+			_ = config.AddSupplier("id2", 1, newSupplier)
+			if data, _ := reader(restLogMwReadersTestContext(), writer, http.StatusOK); data["body"] != "body" {
+				t.Errorf("(%v) when expecting the response body", data["body"])
+			}
+		})
+	})
+}
+
+func Test_RestLogMwEndpointGenerator(t *testing.T) {
+	t.Run("NewRestLogMwEndpointGenerator", func(t *testing.T) {
+		t.Run("nil config", func(t *testing.T) {
+			if _, e := NewRestLogMwEndpointGenerator(nil, slate.NewLog(), &RestLogMwReaderFactory{}); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil logger", func(t *testing.T) {
+			if _, e := NewRestLogMwEndpointGenerator(slate.NewConfig(), nil, &RestLogMwReaderFactory{}); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil factory", func(t *testing.T) {
+			if _, e := NewRestLogMwEndpointGenerator(slate.NewConfig(), slate.NewLog(), nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("invalid rules configuration", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.rules", slate.ConfigPartial{"slow": "string"})
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+
+			if _, e := NewRestLogMwEndpointGenerator(config, slate.NewLog(), &RestLogMwReaderFactory{}); !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("log with the endpoint readers", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			readers := slate.ConfigPartial{
+				"request": []interface{}{},
+				"endpoints": slate.ConfigPartial{
+					"endpoint": slate.ConfigPartial{"request": []interface{}{"json"}},
+				},
+			}
+			config := restLogMwReadersTestConfig(ctrl, readers)
+			redactor, _ := NewRestLogMwRedactor(config)
+			factory, _ := NewRestLogMwReaderFactory(config, slate.NewLog(), redactor, nil)
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.
+				EXPECT().
+				Signal(RestLogMwRequestChannel, RestLogMwRequestLevel, RestLogMwRequestMessage, gomock.Any()).
+				DoAndReturn(func(_ string, _ slate.LogLevel, _ string, ctx ...slate.LogContext) error {
+					req := ctx[0]["request"].(slate.LogContext)
+					if !reflect.DeepEqual(req["bodyJson"], map[string]interface{}{"field": "value"}) {
+						t.Errorf("(%v) unexpected bodyJson field", req["bodyJson"])
+					}
+					return nil
+				}).
+				Times(1)
+			logWriter.EXPECT().Signal(RestLogMwResponseChannel, RestLogMwResponseLevel, RestLogMwResponseMessage, gomock.Any()).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			generator, e := NewRestLogMwEndpointGenerator(config, logger, factory)
+			if e != nil {
+				t.Fatalf("unexpected (%v) error", e)
+			}
+			generator("endpoint", http.StatusOK)(func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})(restLogMwReadersTestContext())
+		})
+	})
+}
+
+func Test_RestLogMwServiceRegister_readers(t *testing.T) {
+	t.Run("retrieving the configured readers and generators", func(t *testing.T) {
+		container := slate.NewServiceContainer()
+		_ = slate.NewFileSystemServiceRegister().Provide(container)
+		_ = slate.NewConfigServiceRegister().Provide(container)
+		_ = slate.NewLogServiceRegister().Provide(container)
+		_ = NewRestLogMwServiceRegister().Provide(container)
+		_ = container.Add("model.provider", func() RestLogMwModelProvider {
+			return restLogMwReadersTestProvider{}
+		}, RestLogMwModelProviderTag)
+
+		if sut, e := container.Get(RestLogMwReaderFactoryContainerID); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if factory, ok := sut.(*RestLogMwReaderFactory); !ok {
+			t.Errorf("didn't returned a valid readers factory : %v", sut)
+		} else if _, ok := factory.models["model"]; !ok {
+			t.Error("didn't collected the tagged model providers")
+		}
+
+		if sut, e := container.Get(RestLogMwRequestReaderContainerID); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if _, ok := sut.(RestLogMwRequestReader); !ok {
+			t.Errorf("didn't returned a valid request reader : %v", sut)
+		}
+
+		if sut, e := container.Get(RestLogMwResponseReaderContainerID); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if _, ok := sut.(RestLogMwResponseReader); !ok {
+			t.Errorf("didn't returned a valid response reader : %v", sut)
+		}
+
+		if sut, e := container.Get(RestLogMwEndpointContainerID); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if _, ok := sut.(RestLogMwEndpointGenerator); !ok {
+			t.Errorf("didn't returned a valid endpoint generator : %v", sut)
+		}
+	})
+}
@@ -0,0 +1,450 @@
+package sapi
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// RestLogMwRedactorContainerID defines the id used to register the
+	// log middleware sensitive data redactor in the application container.
+	RestLogMwRedactorContainerID = RestLogMwContainerID + ".redactor"
+)
+
+var (
+	// RestLogMwConfigPathRedact defines the config path used to store the
+	// log middleware sensitive data redaction rules.
+	RestLogMwConfigPathRedact = slate.EnvString(RestLogMwEnvID+"_CONFIG_PATH_REDACT", "slate.api.rest.log.redact")
+
+	// RestLogMwRedactMask defines the value used to replace the redacted
+	// sensitive data in the logging information.
+	RestLogMwRedactMask = slate.EnvString(RestLogMwEnvID+"_REDACT_MASK", "[REDACTED]")
+
+	// RestLogMwRedactHeaders defines the default list of header names
+	// that will be redacted if no configuration is given.
+	RestLogMwRedactHeaders = slate.EnvList(RestLogMwEnvID+"_REDACT_HEADERS", []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"})
+)
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Redactor
+// ----------------------------------------------------------------------------
+
+type restLogMwRedactRules struct {
+	headers  map[string]bool
+	params   map[string]bool
+	json     map[string]bool
+	xml      []*regexp.Regexp
+	patterns []*regexp.Regexp
+}
+
+// RestLogMwRedactor defines an instance used to remove the sensitive
+// data from the request and response logging information. The redaction
+// rules are obtained from the configuration, where the headers, query
+// parameters, JSON body paths, XML body elements and generic value
+// regular expressions can be defined.
+type RestLogMwRedactor struct {
+	mutex sync.Locker
+	rules *restLogMwRedactRules
+}
+
+// NewRestLogMwRedactor will instantiate a new sensitive data redactor
+// with the rules defined in the configuration.
+func NewRestLogMwRedactor(
+	config *slate.Config,
+) (*RestLogMwRedactor, error) {
+	// check the config argument reference
+	if config == nil {
+		return nil, errNilPointer("config")
+	}
+	// generate the redactor with the default rules
+	redactor := &RestLogMwRedactor{mutex: &sync.Mutex{}}
+	rules, _ := newRestLogMwRedactRules(nil)
+	redactor.rules = rules
+	// check if the redaction rules are present in the configuration
+	if config.Has(RestLogMwConfigPathRedact) {
+		partial, e := config.Partial(RestLogMwConfigPathRedact)
+		if e != nil {
+			return nil, e
+		}
+		if rules, e = newRestLogMwRedactRules(partial); e != nil {
+			return nil, e
+		}
+		redactor.rules = rules
+		// add a config observer for the redaction rules
+		_ = config.AddObserver(RestLogMwConfigPathRedact, func(_ interface{}, new interface{}) {
+			partial, ok := new.(slate.ConfigPartial)
+			if !ok {
+				return
+			}
+			if rules, e := newRestLogMwRedactRules(partial); e == nil {
+				redactor.mutex.Lock()
+				redactor.rules = rules
+				redactor.mutex.Unlock()
+			}
+		})
+	}
+	return redactor, nil
+}
+
+func newRestLogMwRedactRules(
+	partial slate.ConfigPartial,
+) (*restLogMwRedactRules, error) {
+	// parse the redaction rules configuration
+	rc := struct {
+		Headers  []interface{}
+		Params   []interface{}
+		JSON     []interface{}
+		XML      []interface{}
+		Patterns []interface{}
+	}{}
+	for _, h := range RestLogMwRedactHeaders {
+		rc.Headers = append(rc.Headers, h)
+	}
+	if partial != nil {
+		if _, e := partial.Populate("", &rc); e != nil {
+			return nil, e
+		}
+	}
+	// compose the redaction rules
+	rules := &restLogMwRedactRules{
+		headers: restLogMwRedactSet(rc.Headers),
+		params:  restLogMwRedactSet(rc.Params),
+		json:    restLogMwRedactSet(rc.JSON),
+	}
+	for _, v := range rc.XML {
+		if name, ok := v.(string); ok && name != "" {
+			name = regexp.QuoteMeta(name)
+			rules.xml = append(rules.xml, regexp.MustCompile(`(?is)(<`+name+`(?:\s[^>]*)?>).*?(</`+name+`>)`))
+		}
+	}
+	for _, v := range rc.Patterns {
+		pattern, ok := v.(string)
+		if !ok {
+			return nil, errConversion(v, "string")
+		}
+		re, e := regexp.Compile(pattern)
+		if e != nil {
+			return nil, e
+		}
+		rules.patterns = append(rules.patterns, re)
+	}
+	return rules, nil
+}
+
+func restLogMwRedactSet(
+	list []interface{},
+) map[string]bool {
+	set := map[string]bool{}
+	for _, v := range list {
+		if name, ok := v.(string); ok {
+			set[strings.ToLower(name)] = true
+		}
+	}
+	return set
+}
+
+// Redact will remove the sensitive data from the given request or
+// response logging information.
+func (r *RestLogMwRedactor) Redact(
+	data slate.LogContext,
+) slate.LogContext {
+	// retrieve the current redaction rules
+	r.mutex.Lock()
+	rules := r.rules
+	r.mutex.Unlock()
+	// redact all the logging information fields
+	decoded := restLogMwRedactDecoded(data)
+	for field, value := range data {
+		switch field {
+		case "headers":
+			data[field] = rules.names(value, rules.headers)
+		case "params":
+			data[field] = rules.names(value, rules.params)
+		case "body":
+			if decoded {
+				data[field] = RestLogMwRedactMask
+			} else if body, ok := value.(string); ok {
+				data[field] = rules.body(body)
+			}
+		case "bodyJson":
+			data[field] = rules.value(restLogMwRedactGeneric(value), "")
+		case "bodyXml":
+			data[field] = RestLogMwRedactMask
+			if raw, e := xml.Marshal(value); e == nil {
+				data[field] = rules.text(rules.elements(string(raw)))
+			}
+		default:
+			data[field] = rules.value(value, "")
+		}
+	}
+	return data
+}
+
+// RedactQuery will remove the sensitive data from the given raw
+// url-encoded query string, masking the values of the configured params.
+func (r *RestLogMwRedactor) RedactQuery(
+	query string,
+) string {
+	// retrieve the current redaction rules
+	r.mutex.Lock()
+	rules := r.rules
+	r.mutex.Unlock()
+	// redact the query params and patterns
+	return rules.text(rules.query(query))
+}
+
+// RedactHeader will remove the sensitive data from the given header
+// value, masking it if the header name is configured to be redacted.
+func (r *RestLogMwRedactor) RedactHeader(
+	name,
+	value string,
+) string {
+	// retrieve the current redaction rules
+	r.mutex.Lock()
+	rules := r.rules
+	r.mutex.Unlock()
+	// redact the header value
+	if rules.headers[strings.ToLower(name)] {
+		return RestLogMwRedactMask
+	}
+	return rules.text(value)
+}
+
+func (r *restLogMwRedactRules) names(
+	value interface{},
+	names map[string]bool,
+) interface{} {
+	// redact the named entries of the logging information map
+	entries, ok := value.(slate.LogContext)
+	if !ok {
+		return r.value(value, "")
+	}
+	redacted := slate.LogContext{}
+	for name, v := range entries {
+		if names[strings.ToLower(name)] {
+			redacted[name] = RestLogMwRedactMask
+		} else {
+			redacted[name] = r.value(v, "")
+		}
+	}
+	return redacted
+}
+
+func (r *restLogMwRedactRules) body(
+	body string,
+) string {
+	// redact the configured JSON paths if the body is a JSON content
+	if len(r.json) != 0 {
+		var parsed interface{}
+		if e := json.Unmarshal([]byte(body), &parsed); e == nil {
+			if raw, e := json.Marshal(r.value(parsed, "")); e == nil {
+				return string(raw)
+			}
+		}
+	}
+	// redact the configured params if the body is an url-encoded content
+	if !strings.ContainsAny(body, " \t\r\n<>{}") {
+		body = r.query(body)
+	}
+	return r.text(r.elements(body))
+}
+
+func (r *restLogMwRedactRules) query(
+	query string,
+) string {
+	// redact the values of the configured params in an url-encoded list
+	if len(r.params) == 0 {
+		return query
+	}
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		name, _, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		if key, e := url.QueryUnescape(name); e == nil && r.params[strings.ToLower(key)] {
+			pairs[i] = name + "=" + RestLogMwRedactMask
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func (r *restLogMwRedactRules) elements(
+	body string,
+) string {
+	// redact the content of the configured XML elements
+	for _, re := range r.xml {
+		body = re.ReplaceAllString(body, "${1}"+RestLogMwRedactMask+"${2}")
+	}
+	return body
+}
+
+func (r *restLogMwRedactRules) text(
+	value string,
+) string {
+	// redact all the matches of the configured patterns
+	for _, re := range r.patterns {
+		value = re.ReplaceAllString(value, RestLogMwRedactMask)
+	}
+	return value
+}
+
+func (r *restLogMwRedactRules) value(
+	value interface{},
+	path string,
+) interface{} {
+	// check if the value path is marked to be redacted
+	if path != "" && r.json[strings.ToLower(path)] {
+		return RestLogMwRedactMask
+	}
+	// recursively redact the value content
+	switch v := value.(type) {
+	case string:
+		return r.text(v)
+	case slate.LogContext:
+		redacted := slate.LogContext{}
+		for key, item := range v {
+			redacted[key] = r.value(item, restLogMwRedactPath(path, key))
+		}
+		return redacted
+	case map[string]interface{}:
+		redacted := map[string]interface{}{}
+		for key, item := range v {
+			redacted[key] = r.value(item, restLogMwRedactPath(path, key))
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = r.value(item, path)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for i, item := range v {
+			redacted[i] = r.text(item)
+		}
+		return redacted
+	}
+	return value
+}
+
+func restLogMwRedactPath(
+	path,
+	key string,
+) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func restLogMwRedactDecoded(
+	data slate.LogContext,
+) bool {
+	// check if the body was decoded into a format that can't be
+	// redacted in its raw representation (ex: form, yaml or msgpack)
+	for field := range data {
+		switch field {
+		case "body", "bodyJson", "bodyXml":
+		default:
+			if strings.HasPrefix(field, "body") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func restLogMwRedactGeneric(
+	value interface{},
+) interface{} {
+	// convert the decoded model into a generic structure,
+	// so it can be walked by the redaction process
+	raw, e := json.Marshal(value)
+	if e != nil {
+		return value
+	}
+	var generic interface{}
+	if e := json.Unmarshal(raw, &generic); e != nil {
+		return value
+	}
+	return generic
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Reader Redact Decorators
+// ----------------------------------------------------------------------------
+
+// NewRestLogMwRequestReaderRedactDecorator will instantiate a new request
+// event context reader decorator used to remove the sensitive data from
+// the request logging information. This decorator should wrap any other
+// body decorator, so the parsed content is also redacted.
+func NewRestLogMwRequestReaderRedactDecorator(
+	reader RestLogMwRequestReader,
+	redactor *RestLogMwRedactor,
+) (RestLogMwRequestReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// check the redactor argument reference
+	if redactor == nil {
+		return nil, errNilPointer("redactor")
+	}
+	// return the decorated request reader method
+	return func(
+		ctx *gin.Context,
+	) (slate.LogContext, error) {
+		// read the logging request data from the context
+		data, e := reader(ctx)
+		if e != nil {
+			return nil, e
+		}
+		// return the redacted request information
+		return redactor.Redact(data), nil
+	}, nil
+}
+
+// NewRestLogMwResponseReaderRedactDecorator will instantiate a new
+// response event context reader decorator used to remove the sensitive
+// data from the response logging information. This decorator should wrap
+// any other body decorator, so the parsed content is also redacted.
+func NewRestLogMwResponseReaderRedactDecorator(
+	reader RestLogMwResponseReader,
+	redactor *RestLogMwRedactor,
+) (RestLogMwResponseReader, error) {
+	// check the reader argument reference
+	if reader == nil {
+		return nil, errNilPointer("reader")
+	}
+	// check the redactor argument reference
+	if redactor == nil {
+		return nil, errNilPointer("redactor")
+	}
+	// return the decorated response reader method
+	return func(
+		ctx *gin.Context,
+		writer gin.ResponseWriter,
+		statusCode int,
+	) (slate.LogContext, error) {
+		// read the logging response data from the context
+		data, e := reader(ctx, writer, statusCode)
+		if e != nil {
+			return nil, e
+		}
+		// return the redacted response information
+		return redactor.Redact(data), nil
+	}, nil
+}
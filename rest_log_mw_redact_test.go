@@ -0,0 +1,405 @@
+package sapi
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/happyhippyhippo/slate"
+)
+
+func restLogMwRedactTestConfig(
+	ctrl *gomock.Controller,
+	redact slate.ConfigPartial,
+) *slate.Config {
+	partial := slate.ConfigPartial{}
+	if redact != nil {
+		_, _ = partial.Set("slate.api.rest.log.redact", redact)
+	}
+	supplier := NewMockConfigSupplier(ctrl)
+	supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+	config := slate.NewConfig()
+	_ = config.AddSupplier("id", 0, supplier)
+	return config
+}
+
+func Test_RestLogMwRedactor(t *testing.T) {
+	t.Run("NewRestLogMwRedactor", func(t *testing.T) {
+		t.Run("nil config", func(t *testing.T) {
+			if sut, e := NewRestLogMwRedactor(nil); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("invalid redact configuration", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{"headers": "string"})
+
+			if sut, e := NewRestLogMwRedactor(config); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("invalid pattern", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{"patterns": []interface{}{"("}})
+
+			if sut, e := NewRestLogMwRedactor(config); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if e == nil {
+				t.Error("didn't returned the expected error")
+			}
+		})
+
+		t.Run("non-string pattern", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{"patterns": []interface{}{123}})
+
+			if sut, e := NewRestLogMwRedactor(config); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		t.Run("redact default headers", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sut, _ := NewRestLogMwRedactor(restLogMwRedactTestConfig(ctrl, nil))
+			data := slate.LogContext{
+				"headers": slate.LogContext{"Authorization": "Bearer token", "Accept": "application/json"},
+				"method":  "GET",
+			}
+			expected := slate.LogContext{
+				"headers": slate.LogContext{"Authorization": RestLogMwRedactMask, "Accept": "application/json"},
+				"method":  "GET",
+			}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("redact configured headers and params", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"headers": []interface{}{"x-api-key"},
+				"params":  []interface{}{"token"},
+			})
+			sut, _ := NewRestLogMwRedactor(config)
+			data := slate.LogContext{
+				"headers": slate.LogContext{"X-Api-Key": "secret", "Authorization": "Bearer token"},
+				"params":  slate.LogContext{"Token": []string{"a", "b"}, "page": "1"},
+			}
+			expected := slate.LogContext{
+				"headers": slate.LogContext{"X-Api-Key": RestLogMwRedactMask, "Authorization": "Bearer token"},
+				"params":  slate.LogContext{"Token": RestLogMwRedactMask, "page": "1"},
+			}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("redact json paths", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"json": []interface{}{"password", "card.number"},
+			})
+			sut, _ := NewRestLogMwRedactor(config)
+			model := struct {
+				Password string `json:"password"`
+				Name     string `json:"name"`
+			}{Password: "secret", Name: "name"}
+			data := slate.LogContext{
+				"body":     `{"user":"name","card":{"number":"4111111111111111","holder":"name"}}`,
+				"bodyJson": &model,
+			}
+			expected := slate.LogContext{
+				"body":     `{"card":{"holder":"name","number":"[REDACTED]"},"user":"name"}`,
+				"bodyJson": map[string]interface{}{"password": RestLogMwRedactMask, "name": "name"},
+			}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("redact xml elements", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"xml": []interface{}{"password"},
+			})
+			sut, _ := NewRestLogMwRedactor(config)
+			model := struct {
+				XMLName  xml.Name `xml:"user"`
+				Password string   `xml:"password"`
+				Name     string   `xml:"name"`
+			}{Password: "secret", Name: "name"}
+			data := slate.LogContext{
+				"body":    `<user><password type="plain">secret</password><name>name</name></user>`,
+				"bodyXml": &model,
+			}
+			expected := slate.LogContext{
+				"body":    `<user><password type="plain">[REDACTED]</password><name>name</name></user>`,
+				"bodyXml": `<user><password>[REDACTED]</password><name>name</name></user>`,
+			}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("mask a xml model that can't be encoded", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"xml": []interface{}{"password"},
+			})
+			sut, _ := NewRestLogMwRedactor(config)
+			data := slate.LogContext{"bodyXml": map[string]interface{}{"password": "secret"}}
+			expected := slate.LogContext{"bodyXml": RestLogMwRedactMask}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("redact url-encoded body params", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"json":   []interface{}{"password"},
+				"params": []interface{}{"password"},
+			})
+			sut, _ := NewRestLogMwRedactor(config)
+			data := slate.LogContext{"body": "user=a&Pass%77ord=hunter2&empty"}
+			expected := slate.LogContext{"body": "user=a&Pass%77ord=[REDACTED]&empty"}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("mask the raw body of a decoded content", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"json": []interface{}{"password"},
+			})
+			sut, _ := NewRestLogMwRedactor(config)
+			data := slate.LogContext{
+				"body":     "user: a\npassword: hunter2\n",
+				"bodyYaml": map[string]interface{}{"user": "a", "password": "hunter2"},
+			}
+			expected := slate.LogContext{
+				"body":     RestLogMwRedactMask,
+				"bodyYaml": map[string]interface{}{"user": "a", "password": RestLogMwRedactMask},
+			}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("redact patterns", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+				"patterns": []interface{}{`\b\d{13,16}\b`},
+			})
+			sut, _ := NewRestLogMwRedactor(config)
+			data := slate.LogContext{
+				"path":    "/cards/4111111111111111",
+				"headers": slate.LogContext{"X-Card": []string{"4111111111111111"}},
+				"body":    `{"card":"4111111111111111"}`,
+			}
+			expected := slate.LogContext{
+				"path":    "/cards/[REDACTED]",
+				"headers": slate.LogContext{"X-Card": []string{"[REDACTED]"}},
+				"body":    `{"card":"[REDACTED]"}`,
+			}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+
+		t.Run("redact with the updated configuration", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			partial := slate.ConfigPartial{}
+			_, _ = partial.Set("slate.api.rest.log.redact", slate.ConfigPartial{"params": []interface{}{"token"}})
+			partial2 := slate.ConfigPartial{}
+			_, _ = partial2.Set("slate.api.rest.log.redact", slate.ConfigPartial{"params": []interface{}{"key"}})
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+			supplier2 := NewMockConfigSupplier(ctrl)
+			supplier2.EXPECT().Get("").Return(partial2, nil).Times(1)
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			sut, _ := NewRestLogMwRedactor(config)
+			_ = config.AddSupplier("id2", 100, supplier2)
+
+			data := slate.LogContext{"params": slate.LogContext{"token": "value", "key": "value"}}
+			expected := slate.LogContext{"params": slate.LogContext{"token": "value", "key": RestLogMwRedactMask}}
+
+			if check := sut.Redact(data); !reflect.DeepEqual(check, expected) {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
+	})
+	t.Run("RedactQuery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := restLogMwRedactTestConfig(ctrl, slate.ConfigPartial{
+			"params":   []interface{}{"token"},
+			"patterns": []interface{}{`\b\d{13,16}\b`},
+		})
+		sut, _ := NewRestLogMwRedactor(config)
+		expected := "page=1&Token=[REDACTED]&card=[REDACTED]"
+
+		if check := sut.RedactQuery("page=1&Token=abc&card=4111111111111111"); check != expected {
+			t.Errorf("(%v) when expecting (%v)", check, expected)
+		}
+	})
+
+	t.Run("RedactHeader", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sut, _ := NewRestLogMwRedactor(restLogMwRedactTestConfig(ctrl, nil))
+
+		if check := sut.RedactHeader("authorization", "Bearer token"); check != RestLogMwRedactMask {
+			t.Errorf("(%v) when expecting (%v)", check, RestLogMwRedactMask)
+		}
+		if check := sut.RedactHeader("Accept", "application/json"); check != "application/json" {
+			t.Errorf("(%v) when expecting (%v)", check, "application/json")
+		}
+	})
+}
+
+func Test_RestLogMwRequestReaderRedactDecorator(t *testing.T) {
+	t.Run("NewRestLogMwRequestReaderRedactDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwRequestReaderRedactDecorator(nil, &RestLogMwRedactor{}); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil redactor", func(t *testing.T) {
+			if _, e := NewRestLogMwRequestReaderRedactDecorator(NewRestLogMwRequestReader(), nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expected := fmt.Errorf("error message")
+			reader := func(_ *gin.Context) (slate.LogContext, error) { return nil, expected }
+			redactor, _ := NewRestLogMwRedactor(restLogMwRedactTestConfig(ctrl, nil))
+			sut, _ := NewRestLogMwRequestReaderRedactDecorator(reader, redactor)
+
+			if _, e := sut(&gin.Context{}); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		t.Run("redact the request information", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			redactor, _ := NewRestLogMwRedactor(restLogMwRedactTestConfig(ctrl, nil))
+			sut, _ := NewRestLogMwRequestReaderRedactDecorator(NewRestLogMwRequestReader(), redactor)
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.Header.Set("Cookie", "session")
+
+			if data, e := sut(ctx); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if check := data["headers"].(slate.LogContext)["Cookie"]; check != RestLogMwRedactMask {
+				t.Errorf("(%v) when expecting (%v)", check, RestLogMwRedactMask)
+			}
+		})
+	})
+}
+
+func Test_RestLogMwResponseReaderRedactDecorator(t *testing.T) {
+	t.Run("NewRestLogMwResponseReaderRedactDecorator", func(t *testing.T) {
+		t.Run("nil reader", func(t *testing.T) {
+			if _, e := NewRestLogMwResponseReaderRedactDecorator(nil, &RestLogMwRedactor{}); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil redactor", func(t *testing.T) {
+			if _, e := NewRestLogMwResponseReaderRedactDecorator(NewRestLogMwResponseReader(), nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("base reader error", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expected := fmt.Errorf("error message")
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) { return nil, expected }
+			redactor, _ := NewRestLogMwRedactor(restLogMwRedactTestConfig(ctrl, nil))
+			sut, _ := NewRestLogMwResponseReaderRedactDecorator(reader, redactor)
+
+			if _, e := sut(&gin.Context{}, nil, 200); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+
+		t.Run("redact the response information", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			redactor, _ := NewRestLogMwRedactor(restLogMwRedactTestConfig(ctrl, nil))
+			sut, _ := NewRestLogMwResponseReaderRedactDecorator(NewRestLogMwResponseReader(), redactor)
+			gin.SetMode(gin.ReleaseMode)
+			ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+			ctx.Writer.Header().Set("Set-Cookie", "session")
+
+			if data, e := sut(ctx, ctx.Writer, 200); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if check := data["headers"].(slate.LogContext)["Set-Cookie"]; check != RestLogMwRedactMask {
+				t.Errorf("(%v) when expecting (%v)", check, RestLogMwRedactMask)
+			}
+		})
+	})
+}
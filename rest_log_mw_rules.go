@@ -0,0 +1,268 @@
+package sapi
+
+import (
+	"math/rand"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+var (
+	// RestLogMwConfigPathRules defines the config path used to store the
+	// log middleware path exclusion, sampling, slow threshold and level
+	// rules.
+	RestLogMwConfigPathRules = slate.EnvString(RestLogMwEnvID+"_CONFIG_PATH_RULES", "slate.api.rest.log.rules")
+
+	// RestLogMwLogRulesErrorMessage defines the logging message used when
+	// the log middleware rules configuration is invalid.
+	RestLogMwLogRulesErrorMessage = slate.EnvString(RestLogMwEnvID+"_LOG_RULES_ERROR_MESSAGE", "Invalid log middleware rules")
+
+	// RestLogMwConfigPathSignals defines the config path used to store the
+	// channel, level and message of the request, response and slow
+	// request log middleware signals.
+	RestLogMwConfigPathSignals = slate.EnvString(RestLogMwEnvID+"_CONFIG_PATH_SIGNALS", "slate.api.rest.log.signals")
+
+	// RestLogMwLogSignalsErrorMessage defines the logging message used
+	// when the log middleware signals configuration is invalid.
+	RestLogMwLogSignalsErrorMessage = slate.EnvString(RestLogMwEnvID+"_LOG_SIGNALS_ERROR_MESSAGE", "Invalid log middleware signals")
+)
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Rules
+// ----------------------------------------------------------------------------
+
+type restLogMwRule struct {
+	path   string
+	method string
+	rate   int
+	slow   time.Duration
+}
+
+func (r restLogMwRule) match(
+	ctx *gin.Context,
+) bool {
+	// check the request method
+	if ctx.Request != nil && r.method != "" && r.method != "*" && !strings.EqualFold(r.method, ctx.Request.Method) {
+		return false
+	}
+	// check the route path or the request path
+	if r.path == "" || r.path == "*" {
+		return true
+	}
+	if full := ctx.FullPath(); full != "" {
+		if ok, _ := path.Match(r.path, full); ok {
+			return true
+		}
+	}
+	if ctx.Request != nil && ctx.Request.URL != nil {
+		if ok, _ := path.Match(r.path, ctx.Request.URL.Path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type restLogMwRules struct {
+	exclude    []restLogMwRule
+	sampling   []restLogMwRule
+	thresholds []restLogMwRule
+	levels     map[int]slate.LogLevel
+	slow       time.Duration
+}
+
+func newRestLogMwRules(
+	partial slate.ConfigPartial,
+) (*restLogMwRules, error) {
+	rules := &restLogMwRules{
+		levels: map[int]slate.LogLevel{},
+	}
+	if partial == nil {
+		return rules, nil
+	}
+	// parse the path exclusion rules
+	var e error
+	if rules.exclude, e = restLogMwRuleList(partial, "exclude"); e != nil {
+		return nil, e
+	}
+	// parse the successful request sampling rules
+	if rules.sampling, e = restLogMwRuleList(partial, "sampling"); e != nil {
+		return nil, e
+	}
+	// parse the endpoint slow request thresholds
+	if rules.thresholds, e = restLogMwRuleList(partial, "thresholds"); e != nil {
+		return nil, e
+	}
+	// parse the response status class logging levels
+	levels, e := partial.Partial("levels", slate.ConfigPartial{})
+	if e != nil {
+		return nil, e
+	}
+	for key, value := range levels {
+		class, ok := key.(string)
+		if !ok || len(class) != 3 || class[0] < '1' || class[0] > '5' || strings.ToLower(class[1:]) != "xx" {
+			return nil, errConversion(key, "status class")
+		}
+		name, ok := value.(string)
+		if !ok {
+			return nil, errConversion(value, "string")
+		}
+		level, ok := slate.LogLevelMap[strings.ToLower(name)]
+		if !ok {
+			return nil, errConversion(value, "slate.LogLevel")
+		}
+		rules.levels[int(class[0]-'0')] = level
+	}
+	// parse the slow request threshold
+	slow, e := partial.Int("slow", 0)
+	if e != nil {
+		return nil, e
+	}
+	rules.slow = time.Duration(slow) * time.Millisecond
+	return rules, nil
+}
+
+func restLogMwRuleList(
+	partial slate.ConfigPartial,
+	field string,
+) ([]restLogMwRule, error) {
+	list, e := partial.List(field, []interface{}{})
+	if e != nil {
+		return nil, e
+	}
+	var rules []restLogMwRule
+	for _, entry := range list {
+		// type check the rule entry
+		p, ok := entry.(slate.ConfigPartial)
+		if !ok {
+			return nil, errConversion(entry, "slate.ConfigPartial")
+		}
+		// parse the rule entry
+		rc := struct {
+			Path   string
+			Method string
+			Rate   int
+			Slow   int
+		}{Rate: 100}
+		if _, e := p.Populate("", &rc); e != nil {
+			return nil, e
+		}
+		rules = append(rules, restLogMwRule{
+			path:   rc.Path,
+			method: rc.Method,
+			rate:   rc.Rate,
+			slow:   time.Duration(rc.Slow) * time.Millisecond,
+		})
+	}
+	return rules, nil
+}
+
+func (r *restLogMwRules) excluded(
+	ctx *gin.Context,
+) bool {
+	for _, rule := range r.exclude {
+		if rule.match(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *restLogMwRules) sampled(
+	ctx *gin.Context,
+) bool {
+	// the first matching rule defines the sampling percentage
+	for _, rule := range r.sampling {
+		if rule.match(ctx) {
+			return rule.rate >= 100 || (rule.rate > 0 && rand.Intn(100) < rule.rate)
+		}
+	}
+	return true
+}
+
+func (r *restLogMwRules) level(
+	status int,
+	def slate.LogLevel,
+) slate.LogLevel {
+	if level, ok := r.levels[status/100]; ok {
+		return level
+	}
+	return def
+}
+
+func (r *restLogMwRules) threshold(
+	ctx *gin.Context,
+) time.Duration {
+	// the first matching rule defines the endpoint slow threshold
+	for _, rule := range r.thresholds {
+		if rule.match(ctx) {
+			return rule.slow
+		}
+	}
+	return r.slow
+}
+
+// ----------------------------------------------------------------------------
+// Rest Log Middleware Signals
+// ----------------------------------------------------------------------------
+
+type restLogMwSignal struct {
+	channel string
+	level   slate.LogLevel
+	message string
+}
+
+type restLogMwSignals struct {
+	request  restLogMwSignal
+	response restLogMwSignal
+	slow     restLogMwSignal
+}
+
+func newRestLogMwSignals(
+	partial slate.ConfigPartial,
+) (*restLogMwSignals, error) {
+	// compose the signals with the default values
+	signals := &restLogMwSignals{
+		request:  restLogMwSignal{channel: RestLogMwRequestChannel, level: RestLogMwRequestLevel, message: RestLogMwRequestMessage},
+		response: restLogMwSignal{channel: RestLogMwResponseChannel, level: RestLogMwResponseLevel, message: RestLogMwResponseMessage},
+		slow:     restLogMwSignal{channel: RestLogMwSlowChannel, level: RestLogMwSlowLevel, message: RestLogMwSlowMessage},
+	}
+	if partial == nil {
+		return signals, nil
+	}
+	// parse the configured request, response and slow request signals
+	for field, signal := range map[string]*restLogMwSignal{
+		"request":  &signals.request,
+		"response": &signals.response,
+		"slow":     &signals.slow,
+	} {
+		p, e := partial.Partial(field, slate.ConfigPartial{})
+		if e != nil {
+			return nil, e
+		}
+		sc := struct {
+			Channel string
+			Level   string
+			Message string
+		}{Channel: signal.channel, Message: signal.message}
+		if _, e := p.Populate("", &sc); e != nil {
+			return nil, e
+		}
+		signal.channel = sc.Channel
+		signal.message = sc.Message
+		if sc.Level != "" {
+			level, ok := slate.LogLevelMap[strings.ToLower(sc.Level)]
+			if !ok {
+				return nil, errConversion(sc.Level, "slate.LogLevel")
+			}
+			signal.level = level
+		}
+	}
+	return signals, nil
+}
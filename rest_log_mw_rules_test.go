@@ -0,0 +1,192 @@
+package sapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+func restLogMwRulesTestContext(
+	method,
+	path string,
+) *gin.Context {
+	gin.SetMode(gin.ReleaseMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(method, path, nil)
+	return ctx
+}
+
+func Test_restLogMwRules(t *testing.T) {
+	t.Run("newRestLogMwRules", func(t *testing.T) {
+		t.Run("empty rules", func(t *testing.T) {
+			if sut, e := newRestLogMwRules(nil); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if len(sut.exclude) != 0 || len(sut.sampling) != 0 || len(sut.levels) != 0 || sut.slow != 0 {
+				t.Errorf("(%v) unexpected rules", sut)
+			}
+		})
+
+		t.Run("invalid rules", func(t *testing.T) {
+			scenarios := []struct {
+				name    string
+				partial slate.ConfigPartial
+			}{
+				{"invalid exclude list", slate.ConfigPartial{"exclude": "string"}},
+				{"invalid exclude entry", slate.ConfigPartial{"exclude": []interface{}{"string"}}},
+				{"invalid exclude entry field", slate.ConfigPartial{"exclude": []interface{}{slate.ConfigPartial{"path": 123}}}},
+				{"invalid sampling list", slate.ConfigPartial{"sampling": "string"}},
+				{"invalid thresholds list", slate.ConfigPartial{"thresholds": "string"}},
+				{"invalid levels", slate.ConfigPartial{"levels": "string"}},
+				{"invalid level class", slate.ConfigPartial{"levels": slate.ConfigPartial{"9xx": "debug"}}},
+				{"invalid level type", slate.ConfigPartial{"levels": slate.ConfigPartial{"2xx": 123}}},
+				{"invalid level name", slate.ConfigPartial{"levels": slate.ConfigPartial{"2xx": "unknown"}}},
+				{"invalid slow threshold", slate.ConfigPartial{"slow": "string"}},
+			}
+
+			for _, s := range scenarios {
+				if sut, e := newRestLogMwRules(s.partial); sut != nil {
+					t.Errorf("(%s) returned an unexpected valid reference", s.name)
+				} else if !errors.Is(e, slate.ErrConversion) {
+					t.Errorf("(%s) (%v) when expecting (%v)", s.name, e, slate.ErrConversion)
+				}
+			}
+		})
+
+		t.Run("parse rules", func(t *testing.T) {
+			partial := slate.ConfigPartial{
+				"exclude":    []interface{}{slate.ConfigPartial{"path": "/health", "method": "GET"}},
+				"sampling":   []interface{}{slate.ConfigPartial{"path": "/items/*", "rate": 10}},
+				"thresholds": []interface{}{slate.ConfigPartial{"path": "/reports", "slow": 1000}},
+				"levels":     slate.ConfigPartial{"2xx": "debug", "5xx": "error"},
+				"slow":       250,
+			}
+
+			sut, e := newRestLogMwRules(partial)
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case len(sut.exclude) != 1 || sut.exclude[0] != (restLogMwRule{path: "/health", method: "GET", rate: 100}):
+				t.Errorf("(%v) unexpected exclude rules", sut.exclude)
+			case len(sut.sampling) != 1 || sut.sampling[0] != (restLogMwRule{path: "/items/*", rate: 10}):
+				t.Errorf("(%v) unexpected sampling rules", sut.sampling)
+			case len(sut.thresholds) != 1 || sut.thresholds[0] != (restLogMwRule{path: "/reports", rate: 100, slow: time.Second}):
+				t.Errorf("(%v) unexpected threshold rules", sut.thresholds)
+			case sut.levels[2] != slate.DEBUG || sut.levels[5] != slate.ERROR || len(sut.levels) != 2:
+				t.Errorf("(%v) unexpected levels", sut.levels)
+			case sut.slow != 250*time.Millisecond:
+				t.Errorf("(%v) unexpected slow threshold", sut.slow)
+			}
+		})
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		sut := &restLogMwRules{exclude: []restLogMwRule{{path: "/health*", method: "get"}}}
+
+		if !sut.excluded(restLogMwRulesTestContext(http.MethodGet, "/healthz")) {
+			t.Error("didn't excluded the matching request")
+		} else if sut.excluded(restLogMwRulesTestContext(http.MethodPost, "/healthz")) {
+			t.Error("excluded a request with a non-matching method")
+		} else if sut.excluded(restLogMwRulesTestContext(http.MethodGet, "/items")) {
+			t.Error("excluded a request with a non-matching path")
+		}
+	})
+
+	t.Run("sampled", func(t *testing.T) {
+		sut := &restLogMwRules{sampling: []restLogMwRule{
+			{path: "/never", rate: 0},
+			{path: "/always", rate: 100},
+		}}
+
+		if sut.sampled(restLogMwRulesTestContext(http.MethodGet, "/never")) {
+			t.Error("sampled a request with a zero rate")
+		} else if !sut.sampled(restLogMwRulesTestContext(http.MethodGet, "/always")) {
+			t.Error("didn't sampled a request with a full rate")
+		} else if !sut.sampled(restLogMwRulesTestContext(http.MethodGet, "/other")) {
+			t.Error("didn't sampled a request without a matching rule")
+		}
+	})
+
+	t.Run("level", func(t *testing.T) {
+		sut := &restLogMwRules{levels: map[int]slate.LogLevel{4: slate.WARNING}}
+
+		if check := sut.level(404, slate.INFO); check != slate.WARNING {
+			t.Errorf("(%v) when expecting (%v)", check, slate.WARNING)
+		} else if check := sut.level(200, slate.INFO); check != slate.INFO {
+			t.Errorf("(%v) when expecting (%v)", check, slate.INFO)
+		}
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		sut := &restLogMwRules{
+			thresholds: []restLogMwRule{{path: "/reports/*", slow: time.Minute}},
+			slow:       time.Second,
+		}
+
+		if check := sut.threshold(restLogMwRulesTestContext(http.MethodGet, "/reports/1")); check != time.Minute {
+			t.Errorf("(%v) when expecting (%v)", check, time.Minute)
+		} else if check := sut.threshold(restLogMwRulesTestContext(http.MethodGet, "/items")); check != time.Second {
+			t.Errorf("(%v) when expecting (%v)", check, time.Second)
+		}
+	})
+}
+
+func Test_restLogMwSignals(t *testing.T) {
+	t.Run("newRestLogMwSignals", func(t *testing.T) {
+		t.Run("default signals", func(t *testing.T) {
+			expected := restLogMwSignals{
+				request:  restLogMwSignal{channel: RestLogMwRequestChannel, level: RestLogMwRequestLevel, message: RestLogMwRequestMessage},
+				response: restLogMwSignal{channel: RestLogMwResponseChannel, level: RestLogMwResponseLevel, message: RestLogMwResponseMessage},
+				slow:     restLogMwSignal{channel: RestLogMwSlowChannel, level: RestLogMwSlowLevel, message: RestLogMwSlowMessage},
+			}
+
+			if sut, e := newRestLogMwSignals(nil); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if *sut != expected {
+				t.Errorf("(%v) when expecting (%v)", *sut, expected)
+			}
+		})
+
+		t.Run("invalid signals", func(t *testing.T) {
+			scenarios := []struct {
+				name    string
+				partial slate.ConfigPartial
+			}{
+				{"invalid request signal", slate.ConfigPartial{"request": "string"}},
+				{"invalid response channel", slate.ConfigPartial{"response": slate.ConfigPartial{"channel": 123}}},
+				{"invalid slow level", slate.ConfigPartial{"slow": slate.ConfigPartial{"level": "unknown"}}},
+			}
+
+			for _, s := range scenarios {
+				if sut, e := newRestLogMwSignals(s.partial); sut != nil {
+					t.Errorf("(%s) returned an unexpected valid reference", s.name)
+				} else if !errors.Is(e, slate.ErrConversion) {
+					t.Errorf("(%s) (%v) when expecting (%v)", s.name, e, slate.ErrConversion)
+				}
+			}
+		})
+
+		t.Run("parse signals", func(t *testing.T) {
+			partial := slate.ConfigPartial{
+				"request":  slate.ConfigPartial{"channel": "requests", "level": "info"},
+				"response": slate.ConfigPartial{"message": "Handled"},
+				"slow":     slate.ConfigPartial{"level": "Error"},
+			}
+			expected := restLogMwSignals{
+				request:  restLogMwSignal{channel: "requests", level: slate.INFO, message: RestLogMwRequestMessage},
+				response: restLogMwSignal{channel: RestLogMwResponseChannel, level: RestLogMwResponseLevel, message: "Handled"},
+				slow:     restLogMwSignal{channel: RestLogMwSlowChannel, level: slate.ERROR, message: RestLogMwSlowMessage},
+			}
+
+			if sut, e := newRestLogMwSignals(partial); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if *sut != expected {
+				t.Errorf("(%v) when expecting (%v)", *sut, expected)
+			}
+		})
+	})
+}
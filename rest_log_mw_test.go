@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -92,11 +94,25 @@ func Test_envToLogLevel(t *testing.T) {
 		}
 
 		for _, scenario := range scenarios {
-			if chk := envToLogLevel(scenario.input, scenario.def); chk != scenario.expected {
+			t.Setenv("SAPI_TEST_LOG_LEVEL", scenario.input)
+			if chk := envToLogLevel("SAPI_TEST_LOG_LEVEL", scenario.def); chk != scenario.expected {
 				t.Errorf("parsed to  (%v) when expecting (%v)", chk, scenario.expected)
 			}
 		}
 	})
+
+	t.Run("undefined environment variable", func(t *testing.T) {
+		if chk := envToLogLevel("SAPI_TEST_UNDEFINED_LOG_LEVEL", slate.NOTICE); chk != slate.NOTICE {
+			t.Errorf("parsed to  (%v) when expecting (%v)", chk, slate.NOTICE)
+		}
+	})
+
+	t.Run("do not parse the environment variable name", func(t *testing.T) {
+		t.Setenv("ERROR", "")
+		if chk := envToLogLevel("ERROR", slate.NOTICE); chk != slate.NOTICE {
+			t.Errorf("parsed to  (%v) when expecting (%v)", chk, slate.NOTICE)
+		}
+	})
 }
 
 func Test_RestLogMwRequestReader(t *testing.T) {
@@ -180,6 +196,38 @@ func Test_RestLogMwRequestReader(t *testing.T) {
 				}
 			})
 		})
+
+		t.Run("truncate the request body", func(t *testing.T) {
+			prev := RestLogMwBodyMaxSize
+			RestLogMwBodyMaxSize = 4
+			defer func() { RestLogMwBodyMaxSize = prev }()
+
+			ctx := &gin.Context{}
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "http://domain/resource", bytes.NewBufferString("0123456789"))
+
+			data, _ := NewRestLogMwRequestReader()(ctx)
+			expected := "0123" + RestLogMwBodyTruncatedMarker
+
+			if value := data["body"]; value != expected {
+				t.Errorf("stored the (%v) body when expecting (%v)", value, expected)
+			} else if body, _ := io.ReadAll(ctx.Request.Body); string(body) != "0123456789" {
+				t.Errorf("handler read the (%v) body", string(body))
+			}
+		})
+
+		t.Run("skip the request body of skipped content types", func(t *testing.T) {
+			ctx := &gin.Context{}
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "http://domain/resource", bytes.NewBufferString("binary"))
+			ctx.Request.Header.Set("Content-Type", "image/png")
+
+			data, _ := NewRestLogMwRequestReader()(ctx)
+
+			if value := data["body"]; value != RestLogMwBodySkippedMarker {
+				t.Errorf("stored the (%v) body when expecting (%v)", value, RestLogMwBodySkippedMarker)
+			} else if body, _ := io.ReadAll(ctx.Request.Body); string(body) != "binary" {
+				t.Errorf("handler read the (%v) body", string(body))
+			}
+		})
 	})
 }
 
@@ -586,6 +634,29 @@ func Test_restLogMwResponseWriter(t *testing.T) {
 				t.Errorf("written (%v) bytes on buffer", writer.body)
 			}
 		})
+
+		t.Run("limit the buffered content", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			b1 := []byte{1, 2, 3}
+			b2 := []byte{4, 5, 6}
+			ginWriter := NewMockResponseWriter(ctrl)
+			ginWriter.EXPECT().Write(b1).Return(3, nil).Times(1)
+			ginWriter.EXPECT().Write(b2).Return(3, nil).Times(1)
+			writer, _ := newRestLogMwResponseWriter(ginWriter)
+			writer.limit = 4
+
+			if n, _ := writer.Write(b1); n != 3 {
+				t.Errorf("(%v) written bytes", n)
+			} else if n, _ := writer.Write(b2); n != 3 {
+				t.Errorf("(%v) written bytes", n)
+			} else if !reflect.DeepEqual(writer.body.Bytes(), []byte{1, 2, 3, 4}) {
+				t.Errorf("written (%v) bytes on buffer", writer.body)
+			} else if !writer.truncated {
+				t.Error("didn't flagged the buffer as truncated")
+			}
+		})
 	})
 
 	t.Run("body", func(t *testing.T) {
@@ -602,6 +673,21 @@ func Test_restLogMwResponseWriter(t *testing.T) {
 				t.Errorf("written (%v) bytes on buffer", writer.body)
 			}
 		})
+
+		t.Run("append the truncation marker", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ginWriter := NewMockResponseWriter(ctrl)
+			writer, _ := newRestLogMwResponseWriter(ginWriter)
+			writer.body = bytes.NewBufferString("body")
+			writer.truncated = true
+			expected := "body" + RestLogMwBodyTruncatedMarker
+
+			if check := string(writer.Body()); check != expected {
+				t.Errorf("(%v) when expecting (%v)", check, expected)
+			}
+		})
 	})
 }
 
@@ -665,6 +751,45 @@ func Test_RestLogMwResponseReader(t *testing.T) {
 				t.Errorf("stored the (%v) body", value)
 			}
 		})
+
+		t.Run("store the body if flagged to always store it", func(t *testing.T) {
+			prev := RestLogMwResponseBodyAlways
+			RestLogMwResponseBodyAlways = true
+			defer func() { RestLogMwResponseBodyAlways = prev }()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			statusCode := 200
+			rawBody := []byte(`{"field":"value"}`)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Status().Return(statusCode).Times(1)
+			writer.EXPECT().Header().Return(http.Header{}).Times(1)
+			writer.EXPECT().Body().Return(rawBody).Times(1)
+
+			if data, e := NewRestLogMwResponseReader()(nil, writer, statusCode); e != nil {
+				t.Errorf("returned the unextected (%v) error", e)
+			} else if value := data["body"]; !reflect.DeepEqual(value, string(rawBody)) {
+				t.Errorf("stored the (%v) body", value)
+			}
+		})
+
+		t.Run("skip the body of skipped content types", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			statusCode := 200
+			headers := map[string][]string{"Content-Type": {"application/octet-stream"}}
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Status().Return(statusCode).Times(1)
+			writer.EXPECT().Header().Return(headers).Times(1)
+
+			if data, e := NewRestLogMwResponseReader()(nil, writer, statusCode+1); e != nil {
+				t.Errorf("returned the unextected (%v) error", e)
+			} else if value := data["body"]; value != RestLogMwBodySkippedMarker {
+				t.Errorf("stored the (%v) body", value)
+			}
+		})
 	})
 }
 
@@ -754,6 +879,7 @@ func Test_RestLogMwResponseReaderJSONDecorator(t *testing.T) {
 			ctx.Request = &http.Request{}
 			ctx.Request.Header = http.Header{}
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -781,6 +907,7 @@ func Test_RestLogMwResponseReaderJSONDecorator(t *testing.T) {
 			ctx.Request = &http.Request{}
 			ctx.Request.Header = http.Header{}
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -809,6 +936,7 @@ func Test_RestLogMwResponseReaderJSONDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", gin.MIMEXML)
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -837,6 +965,7 @@ func Test_RestLogMwResponseReaderJSONDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", gin.MIMEJSON)
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -866,6 +995,7 @@ func Test_RestLogMwResponseReaderJSONDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", gin.MIMEJSON)
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -897,6 +1027,39 @@ func Test_RestLogMwResponseReaderJSONDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", "*/*")
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return data, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderJSONDecorator(reader, nil)
+
+			result, e := decorator(ctx, writer, 0)
+			switch {
+			case e != nil:
+				t.Errorf("returned the unexpected (%v) error", e)
+			case result == nil:
+				t.Error("didn't returned the expected context data")
+			default:
+				if body, ok := result["bodyJson"]; !ok {
+					t.Error("didn't added the bodyJson field")
+				} else if !reflect.DeepEqual(body, expected) {
+					t.Errorf("(%v) when expecting (%v)", body, expected)
+				}
+			}
+		})
+
+		t.Run("correctly add decorated field for a JSON response content type", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			data := slate.LogContext{"body": `{"field":"value"}`}
+			expected := map[string]interface{}{"field": "value"}
+			ctx := &gin.Context{}
+			ctx.Request = &http.Request{}
+			ctx.Request.Header = http.Header{}
+			ctx.Request.Header.Add("Accept", "text/html")
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {"application/problem+json; charset=utf-8"}}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -916,6 +1079,35 @@ func Test_RestLogMwResponseReaderJSONDecorator(t *testing.T) {
 				}
 			}
 		})
+
+		t.Run("ignore the accepted type if the response defines its content type", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			data := slate.LogContext{"body": `{"field":"value"}`}
+			ctx := &gin.Context{}
+			ctx.Request = &http.Request{}
+			ctx.Request.Header = http.Header{}
+			ctx.Request.Header.Add("Accept", gin.MIMEJSON)
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {gin.MIMEHTML}}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return data, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderJSONDecorator(reader, nil)
+
+			result, e := decorator(ctx, writer, 0)
+			switch {
+			case e != nil:
+				t.Errorf("returned the unexpected (%v) error", e)
+			case result == nil:
+				t.Error("didn't returned the expected context data")
+			default:
+				if _, ok := result["bodyJson"]; ok {
+					t.Error("added the bodyJson field")
+				}
+			}
+		})
 	})
 }
 
@@ -980,6 +1172,7 @@ func Test_RestLogMwResponseReaderXMLDecorator(t *testing.T) {
 			ctx.Request = &http.Request{}
 			ctx.Request.Header = http.Header{}
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return nil, expected
 			}
@@ -1005,6 +1198,7 @@ func Test_RestLogMwResponseReaderXMLDecorator(t *testing.T) {
 			ctx.Request = &http.Request{}
 			ctx.Request.Header = http.Header{}
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -1036,6 +1230,7 @@ func Test_RestLogMwResponseReaderXMLDecorator(t *testing.T) {
 			ctx.Request = &http.Request{}
 			ctx.Request.Header = http.Header{}
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -1068,6 +1263,7 @@ func Test_RestLogMwResponseReaderXMLDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", gin.MIMEJSON)
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -1100,6 +1296,7 @@ func Test_RestLogMwResponseReaderXMLDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", gin.MIMEXML)
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -1136,6 +1333,7 @@ func Test_RestLogMwResponseReaderXMLDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", gin.MIMEXML)
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -1174,6 +1372,46 @@ func Test_RestLogMwResponseReaderXMLDecorator(t *testing.T) {
 			ctx.Request.Header = http.Header{}
 			ctx.Request.Header.Add("Accept", gin.MIMEXML2)
 			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{}).AnyTimes()
+			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
+				return data, nil
+			}
+			decorator, _ := NewRestLogMwResponseReaderXMLDecorator(reader, &model)
+
+			result, e := decorator(ctx, writer, 0)
+			switch {
+			case e != nil:
+				t.Errorf("returned the unexpected (%v) error", e)
+			case result == nil:
+				t.Error("didn't returned the expected context data")
+			default:
+				if body, ok := result["bodyXml"]; !ok {
+					t.Error("didn't added the bodyXml field")
+				} else if !reflect.DeepEqual(body, &expected) {
+					t.Errorf("(%v) when expecting (%v)", body, &expected)
+				}
+			}
+		})
+
+		t.Run("correctly add decorated field for a XML response content type", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			data := slate.LogContext{"body": "<message><field>value</field></message>"}
+			model := struct {
+				XMLName xml.Name `xml:"message"`
+				Field   string   `xml:"field"`
+			}{}
+			expected := struct {
+				XMLName xml.Name `xml:"message"`
+				Field   string   `xml:"field"`
+			}{XMLName: xml.Name{Local: "message"}, Field: "value"}
+			ctx := &gin.Context{}
+			ctx.Request = &http.Request{}
+			ctx.Request.Header = http.Header{}
+			ctx.Request.Header.Add("Accept", "*/*")
+			writer := NewMockResponseWriter(ctrl)
+			writer.EXPECT().Header().Return(http.Header{"Content-Type": {gin.MIMEXML + "; charset=utf-8"}}).AnyTimes()
 			reader := func(_ *gin.Context, _ gin.ResponseWriter, _ int) (slate.LogContext, error) {
 				return data, nil
 			}
@@ -1288,6 +1526,7 @@ func Test_RestLogMwGenerator(t *testing.T) {
 			writer := NewMockResponseWriter(ctrl)
 			ctx := &gin.Context{}
 			ctx.Writer = writer
+			RestSetRequestID(ctx, "request-id")
 			callCount := 0
 			var next gin.HandlerFunc = func(context *gin.Context) {
 				if context != ctx {
@@ -1306,7 +1545,7 @@ func Test_RestLogMwGenerator(t *testing.T) {
 						RestLogMwRequestChannel,
 						RestLogMwRequestLevel,
 						RestLogMwRequestMessage,
-						slate.LogContext{"request": req},
+						slate.LogContext{RestRequestIDMwLogField: "request-id", "request": req},
 					),
 				logWriter.
 					EXPECT().
@@ -1314,7 +1553,7 @@ func Test_RestLogMwGenerator(t *testing.T) {
 						RestLogMwResponseChannel,
 						RestLogMwResponseLevel,
 						RestLogMwResponseMessage,
-						slate.LogContext{"request": req, "response": res, "duration": int64(0)},
+						gomock.Any(),
 					),
 			)
 			logger := slate.NewLog()
@@ -1347,6 +1586,314 @@ func Test_RestLogMwGenerator(t *testing.T) {
 	})
 }
 
+func Test_RestLogMwConfigGenerator(t *testing.T) {
+	reqReader := func(ctx *gin.Context) (slate.LogContext, error) { return nil, nil }
+	resReader := func(ctx *gin.Context, writer gin.ResponseWriter, statusCode int) (slate.LogContext, error) {
+		return nil, nil
+	}
+
+	t.Run("NewRestLogMwConfigGenerator", func(t *testing.T) {
+		scenarios := []struct {
+			name           string
+			config         *slate.Config
+			logger         *slate.Log
+			requestReader  RestLogMwRequestReader
+			responseReader RestLogMwResponseReader
+		}{
+			{name: "nil config", logger: slate.NewLog(), requestReader: reqReader, responseReader: resReader},
+			{name: "nil logger", config: slate.NewConfig(), requestReader: reqReader, responseReader: resReader},
+			{name: "nil request reader", config: slate.NewConfig(), logger: slate.NewLog(), responseReader: resReader},
+			{name: "nil response reader", config: slate.NewConfig(), logger: slate.NewLog(), requestReader: reqReader},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				generator, e := NewRestLogMwConfigGenerator(scenario.config, scenario.logger, scenario.requestReader, scenario.responseReader)
+				switch {
+				case e == nil:
+					t.Errorf("didn't returned the expected error")
+				case !errors.Is(e, slate.ErrNilPointer):
+					t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+				case generator != nil:
+					t.Error("unexpected valid middleware generator reference")
+				}
+			})
+		}
+
+		t.Run("new log middleware generator", func(t *testing.T) {
+			if generator, e := NewRestLogMwConfigGenerator(slate.NewConfig(), slate.NewLog(), reqReader, resReader); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if generator == nil {
+				t.Error("didn't returned a valid reference")
+			}
+		})
+	})
+}
+
+func Test_RestLogMwConfigGenerator_rules(t *testing.T) {
+	reqReader := func(ctx *gin.Context) (slate.LogContext, error) { return slate.LogContext{}, nil }
+	resReader := func(ctx *gin.Context, writer gin.ResponseWriter, statusCode int) (slate.LogContext, error) {
+		return slate.LogContext{}, nil
+	}
+	config := func(ctrl *gomock.Controller, rules interface{}) *slate.Config {
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.log.rules", rules)
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		return config
+	}
+	run := func(generator RestLogMwGenerator, method, path string, status int) {
+		gin.SetMode(gin.ReleaseMode)
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(method, path, nil)
+		generator(200)(func(ctx *gin.Context) {
+			ctx.Status(status)
+		})(ctx)
+	}
+
+	t.Run("invalid rules configuration", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.EXPECT().Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogRulesErrorMessage, gomock.Any()).Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		generator, e := NewRestLogMwConfigGenerator(config(ctrl, slate.ConfigPartial{"slow": "string"}), logger, reqReader, resReader)
+		switch {
+		case generator != nil:
+			t.Error("unexpected valid middleware generator reference")
+		case !errors.Is(e, slate.ErrConversion):
+			t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+		}
+	})
+
+	t.Run("skip excluded requests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logWriter := NewMockLogWriter(ctrl)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+		rules := slate.ConfigPartial{"exclude": []interface{}{slate.ConfigPartial{"path": "/health"}}}
+
+		generator, _ := NewRestLogMwConfigGenerator(config(ctrl, rules), logger, reqReader, resReader)
+		run(generator, http.MethodGet, "/health", 200)
+	})
+
+	t.Run("skip non-sampled successful requests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logWriter := NewMockLogWriter(ctrl)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+		rules := slate.ConfigPartial{"sampling": []interface{}{slate.ConfigPartial{"path": "/items", "rate": 0}}}
+
+		generator, _ := NewRestLogMwConfigGenerator(config(ctrl, rules), logger, reqReader, resReader)
+		run(generator, http.MethodGet, "/items", 200)
+	})
+
+	t.Run("log non-sampled error requests with the status class level", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logWriter := NewMockLogWriter(ctrl)
+		gomock.InOrder(
+			logWriter.EXPECT().Signal(RestLogMwRequestChannel, RestLogMwRequestLevel, RestLogMwRequestMessage, gomock.Any()).Times(1),
+			logWriter.EXPECT().Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwResponseMessage, gomock.Any()).Times(1),
+		)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+		rules := slate.ConfigPartial{
+			"sampling": []interface{}{slate.ConfigPartial{"path": "/items", "rate": 0}},
+			"levels":   slate.ConfigPartial{"5xx": "error"},
+		}
+
+		generator, _ := NewRestLogMwConfigGenerator(config(ctrl, rules), logger, reqReader, resReader)
+		run(generator, http.MethodGet, "/items", 500)
+	})
+
+	t.Run("use the updated rules", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logWriter := NewMockLogWriter(ctrl)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.log.rules", slate.ConfigPartial{"slow": 100})
+		newPartial := slate.ConfigPartial{}
+		_, _ = newPartial.Set("slate.api.rest.log.rules.exclude", []interface{}{slate.ConfigPartial{"path": "/items"}})
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		newSource := NewMockConfigSupplier(ctrl)
+		newSource.EXPECT().Get("").Return(newPartial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id1", 0, supplier)
+
+		generator, _ := NewRestLogMwConfigGenerator(config, logger, reqReader, resReader)
+		_ = config.AddSupplier("id2", 1, newSource)
+		run(generator, http.MethodGet, "/items", 200)
+	})
+
+	t.Run("signal slow requests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		logWriter := NewMockLogWriter(ctrl)
+		gomock.InOrder(
+			logWriter.EXPECT().Signal(RestLogMwRequestChannel, RestLogMwRequestLevel, RestLogMwRequestMessage, gomock.Any()).Times(1),
+			logWriter.
+				EXPECT().
+				Signal(RestLogMwResponseChannel, RestLogMwResponseLevel, RestLogMwResponseMessage, gomock.Any()).
+				DoAndReturn(func(_ string, _ slate.LogLevel, _ string, ctx slate.LogContext) error {
+					if check := ctx["duration"].(int64); check < 1 {
+						t.Errorf("(%v) milliseconds duration lower than the threshold", check)
+					}
+					if check := ctx["duration_ns"].(int64); check < time.Millisecond.Nanoseconds() {
+						t.Errorf("(%v) nanoseconds duration lower than the threshold", check)
+					}
+					return nil
+				}).
+				Times(1),
+			logWriter.
+				EXPECT().
+				Signal(RestLogMwSlowChannel, RestLogMwSlowLevel, RestLogMwSlowMessage, gomock.Any()).
+				DoAndReturn(func(_ string, _ slate.LogLevel, _ string, ctx slate.LogContext) error {
+					if check := ctx["threshold"]; check != int64(1) {
+						t.Errorf("(%v) threshold when expecting (1)", check)
+					}
+					if check := ctx["duration"].(int64); check < 1 {
+						t.Errorf("(%v) milliseconds duration lower than the threshold", check)
+					}
+					if check := ctx["duration_ns"].(int64); check < time.Millisecond.Nanoseconds() {
+						t.Errorf("(%v) nanoseconds duration lower than the threshold", check)
+					}
+					timing, ok := ctx["timing"].(slate.LogContext)
+					if !ok {
+						t.Errorf("(%v) invalid timing breakdown", ctx["timing"])
+					} else if check := timing["handler"].(int64); check < time.Millisecond.Nanoseconds() {
+						t.Errorf("(%v) handler timing lower than the threshold", check)
+					}
+					return nil
+				}).
+				Times(1),
+		)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+		rules := slate.ConfigPartial{
+			"sampling":   []interface{}{slate.ConfigPartial{"path": "/reports", "rate": 0}},
+			"thresholds": []interface{}{slate.ConfigPartial{"path": "/reports", "slow": 1}},
+		}
+
+		generator, _ := NewRestLogMwConfigGenerator(config(ctrl, rules), logger, reqReader, resReader)
+		gin.SetMode(gin.ReleaseMode)
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/reports", nil)
+		generator(200)(func(ctx *gin.Context) {
+			time.Sleep(2 * time.Millisecond)
+		})(ctx)
+	})
+}
+
+func Test_RestLogMwConfigGenerator_signals(t *testing.T) {
+	reqReader := func(ctx *gin.Context) (slate.LogContext, error) { return slate.LogContext{}, nil }
+	resReader := func(ctx *gin.Context, writer gin.ResponseWriter, statusCode int) (slate.LogContext, error) {
+		return slate.LogContext{}, nil
+	}
+	run := func(generator RestLogMwGenerator) {
+		gin.SetMode(gin.ReleaseMode)
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		generator(200)(func(ctx *gin.Context) {
+			ctx.Status(200)
+		})(ctx)
+	}
+
+	t.Run("invalid signals configuration", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.log.signals", slate.ConfigPartial{"request": slate.ConfigPartial{"level": "unknown"}})
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.EXPECT().Signal(RestLogMwResponseChannel, slate.ERROR, RestLogMwLogSignalsErrorMessage, gomock.Any()).Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		generator, e := NewRestLogMwConfigGenerator(config, logger, reqReader, resReader)
+		switch {
+		case generator != nil:
+			t.Error("unexpected valid middleware generator reference")
+		case !errors.Is(e, slate.ErrConversion):
+			t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+		}
+	})
+
+	t.Run("use the configured signals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.log.signals", slate.ConfigPartial{
+			"request":  slate.ConfigPartial{"channel": "requests", "level": "notice", "message": "In"},
+			"response": slate.ConfigPartial{"channel": "responses", "level": "warning", "message": "Out"},
+		})
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		gomock.InOrder(
+			logWriter.EXPECT().Signal("requests", slate.NOTICE, "In", gomock.Any()).Times(1),
+			logWriter.EXPECT().Signal("responses", slate.WARNING, "Out", gomock.Any()).Times(1),
+		)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		generator, _ := NewRestLogMwConfigGenerator(config, logger, reqReader, resReader)
+		run(generator)
+	})
+
+	t.Run("use the updated signals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.rest.log.signals", slate.ConfigPartial{})
+		newPartial := slate.ConfigPartial{}
+		_, _ = newPartial.Set("slate.api.rest.log.signals", slate.ConfigPartial{
+			"request":  slate.ConfigPartial{"channel": "requests"},
+			"response": slate.ConfigPartial{"channel": "responses", "level": "error"},
+		})
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		newSupplier := NewMockConfigSupplier(ctrl)
+		newSupplier.EXPECT().Get("").Return(newPartial, nil).Times(1)
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id1", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		gomock.InOrder(
+			logWriter.EXPECT().Signal("requests", RestLogMwRequestLevel, RestLogMwRequestMessage, gomock.Any()).Times(1),
+			logWriter.EXPECT().Signal("responses", slate.ERROR, RestLogMwResponseMessage, gomock.Any()).Times(1),
+		)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		generator, _ := NewRestLogMwConfigGenerator(config, logger, reqReader, resReader)
+		_ = config.AddSupplier("id2", 1, newSupplier)
+		run(generator)
+	})
+}
+
 func Test_RestLogMwServiceRegister(t *testing.T) {
 	t.Run("NewRestLogMwServiceRegister", func(t *testing.T) {
 		t.Run("create", func(t *testing.T) {
@@ -1384,6 +1931,16 @@ func Test_RestLogMwServiceRegister(t *testing.T) {
 				t.Errorf("unexpected (%v) error", e)
 			case !container.Has(RestLogMwContainerID):
 				t.Errorf("no log middleware generator : %v", sut)
+			case !container.Has(RestLogMwRedactorContainerID):
+				t.Errorf("no log middleware redactor : %v", sut)
+			case !container.Has(RestLogMwEndpointContainerID):
+				t.Errorf("no log middleware endpoint generator : %v", sut)
+			case !container.Has(RestLogMwReaderFactoryContainerID):
+				t.Errorf("no log middleware readers factory : %v", sut)
+			case !container.Has(RestLogMwRequestReaderContainerID):
+				t.Errorf("no log middleware request reader : %v", sut)
+			case !container.Has(RestLogMwResponseReaderContainerID):
+				t.Errorf("no log middleware response reader : %v", sut)
 			}
 		})
 
@@ -1397,9 +1954,6 @@ func Test_RestLogMwServiceRegister(t *testing.T) {
 			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewRestLogMwServiceRegister().Provide(container)
 
-			_ = container.Add("request.reader", NewRestLogMwRequestReader)
-			_ = container.Add("response.reader", NewRestLogMwResponseReader)
-
 			sut, e := container.Get(RestLogMwContainerID)
 			switch {
 			case e != nil:
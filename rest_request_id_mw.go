@@ -0,0 +1,258 @@
+package sapi
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// RestRequestIDMwContainerID defines the default id used to register
+	// the application request id middleware and related services.
+	RestRequestIDMwContainerID = RestContainerID + ".request_id.mw"
+
+	// RestRequestIDMwEnvID defines the request id middleware module base
+	// environment variable name.
+	RestRequestIDMwEnvID = RestEnvID + "_REQUEST_ID_MW"
+)
+
+var (
+	// RestRequestIDMwHeader defines the request header used to obtain the
+	// caller request id, and the response header used to echo it. It
+	// defaults to the envelope middleware request id header.
+	RestRequestIDMwHeader = slate.EnvString(RestRequestIDMwEnvID+"_HEADER", RestEnvelopeMwRequestIDHeader)
+
+	// RestRequestIDMwTraceParentHeader defines the W3C trace context
+	// request header from where the trace id is used as the request id if
+	// the request id header is not present.
+	RestRequestIDMwTraceParentHeader = slate.EnvString(RestRequestIDMwEnvID+"_TRACE_PARENT_HEADER", "traceparent")
+
+	// RestRequestIDMwMaxLength defines the maximum length of an accepted
+	// caller request id. Longer ids are discarded and a new one generated.
+	RestRequestIDMwMaxLength = slate.EnvInt(RestRequestIDMwEnvID+"_MAX_LENGTH", 128)
+
+	// RestRequestIDMwContextField defines the context field used to store
+	// the request id.
+	RestRequestIDMwContextField = slate.EnvString(RestRequestIDMwEnvID+"_CONTEXT_FIELD", "sapi_request_id")
+
+	// RestRequestIDMwLogField defines the logging context field used to
+	// store the request id in the logging entries.
+	RestRequestIDMwLogField = slate.EnvString(RestRequestIDMwEnvID+"_LOG_FIELD", "requestId")
+)
+
+// ----------------------------------------------------------------------------
+// Rest Request ID Context Handlers
+// ----------------------------------------------------------------------------
+
+// RestGetRequestID will retrieve the request id stored in the context.
+// If no id was yet assigned to the request, it will be obtained from the
+// request id or trace parent headers, or generated if none is present,
+// and stored in the context.
+func RestGetRequestID(
+	ctx *gin.Context,
+) string {
+	if ctx == nil {
+		return ""
+	}
+	// check if the request id was already assigned
+	if val, exists := ctx.Get(RestRequestIDMwContextField); exists {
+		if id, ok := val.(string); ok && id != "" {
+			return id
+		}
+	}
+	// obtain the request id from the request headers or generate a new one
+	id := ""
+	if ctx.Request != nil {
+		id = restRequestIDFromHeader(ctx.Request.Header.Get(RestRequestIDMwHeader))
+		if id == "" {
+			id = restRequestIDFromTraceParent(ctx.Request.Header.Get(RestRequestIDMwTraceParentHeader))
+		}
+	}
+	if id == "" {
+		id = restRequestIDGenerate()
+	}
+	ctx.Set(RestRequestIDMwContextField, id)
+	return id
+}
+
+// RestSetRequestID will store the given request id in the context.
+func RestSetRequestID(
+	ctx *gin.Context,
+	id string,
+) *gin.Context {
+	ctx.Set(RestRequestIDMwContextField, id)
+	return ctx
+}
+
+// RestLogContext will compose a logging context with the request id of
+// the given request context merged with the given logging contexts, so
+// the handlers can correlate their logging entries with the request.
+func RestLogContext(
+	ctx *gin.Context,
+	lctx ...slate.LogContext,
+) slate.LogContext {
+	result := slate.LogContext{}
+	for _, c := range lctx {
+		for k, v := range c {
+			result[k] = v
+		}
+	}
+	if ctx != nil {
+		result[RestRequestIDMwLogField] = RestGetRequestID(ctx)
+	}
+	return result
+}
+
+// RestSignal will send a logging signal to the given logger with the
+// request id of the given request context added to the logging context.
+func RestSignal(
+	ctx *gin.Context,
+	logger *slate.Log,
+	channel string,
+	level slate.LogLevel,
+	msg string,
+	lctx ...slate.LogContext,
+) error {
+	// check logger argument reference
+	if logger == nil {
+		return errNilPointer("logger")
+	}
+	return logger.Signal(channel, level, msg, RestLogContext(ctx, lctx...))
+}
+
+// RestPropagateRequestID will add the request id of the given request
+// context to the headers of an outgoing downstream request.
+func RestPropagateRequestID(
+	ctx *gin.Context,
+	req *http.Request,
+) *http.Request {
+	if req == nil {
+		return nil
+	}
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Set(RestRequestIDMwHeader, RestGetRequestID(ctx))
+	return req
+}
+
+func restRequestIDFromHeader(
+	id string,
+) string {
+	// discard empty, oversized or non-printable ids, so they can't be used
+	// to inject content into the response headers or the logs
+	id = strings.TrimSpace(id)
+	if id == "" || (RestRequestIDMwMaxLength > 0 && len(id) > RestRequestIDMwMaxLength) {
+		return ""
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return ""
+		}
+	}
+	return id
+}
+
+func restRequestIDFromTraceParent(
+	traceParent string,
+) string {
+	// parse the trace parent header (version-traceid-parentid-flags)
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || parts[0] == "ff" {
+		return ""
+	}
+	traceID := strings.ToLower(parts[1])
+	if strings.Trim(traceID, "0") == "" {
+		return ""
+	}
+	for _, c := range traceID {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return ""
+		}
+	}
+	return traceID
+}
+
+func restRequestIDGenerate() string {
+	// generate a random (version 4) UUID
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// ----------------------------------------------------------------------------
+// Rest Request ID Middleware Generator
+// ----------------------------------------------------------------------------
+
+// RestRequestIDMwGenerator defines the function used to generate the
+// request id middleware.
+type RestRequestIDMwGenerator func() RestMiddleware
+
+// NewRestRequestIDMwGenerator instantiates a new request id middleware
+// generator. The generated middlewares will assign the request id to the
+// request context and echo it in the response headers. This middleware
+// should be the outermost one, so all the other middlewares can
+// report the same request id.
+func NewRestRequestIDMwGenerator() (RestRequestIDMwGenerator, error) {
+	// return the middleware generator function
+	return func() RestMiddleware {
+		return func(
+			next gin.HandlerFunc,
+		) gin.HandlerFunc {
+			// return the middleware handler function
+			return func(
+				ctx *gin.Context,
+			) {
+				// assign the request id and echo it in the response
+				ctx.Header(RestRequestIDMwHeader, RestGetRequestID(ctx))
+				if next != nil {
+					next(ctx)
+				}
+			}
+		}
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+// Rest Request ID Middleware Service Register
+// ----------------------------------------------------------------------------
+
+// RestRequestIDMwServiceRegister defines the request id middleware provider
+// to be used on the application initialization to register the request id
+// middleware generator.
+type RestRequestIDMwServiceRegister struct {
+	slate.ServiceRegister
+}
+
+var _ slate.ServiceProvider = &RestRequestIDMwServiceRegister{}
+
+// NewRestRequestIDMwServiceRegister will generate a new registry instance
+func NewRestRequestIDMwServiceRegister(
+	app ...*slate.App,
+) *RestRequestIDMwServiceRegister {
+	return &RestRequestIDMwServiceRegister{
+		ServiceRegister: *slate.NewServiceRegister(app...),
+	}
+}
+
+// Provide will add to the container the request id middleware generator.
+func (RestRequestIDMwServiceRegister) Provide(
+	container *slate.ServiceContainer,
+) error {
+	// check container argument reference
+	if container == nil {
+		return errNilPointer("container")
+	}
+	_ = container.Add(RestRequestIDMwContainerID, NewRestRequestIDMwGenerator)
+	return nil
+}
@@ -0,0 +1,245 @@
+package sapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/happyhippyhippo/slate"
+)
+
+func Test_RestGetRequestID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	t.Run("nil context", func(t *testing.T) {
+		if id := RestGetRequestID(nil); id != "" {
+			t.Errorf("returned the unexpected (%v) id", id)
+		}
+	})
+
+	t.Run("return the stored id", func(t *testing.T) {
+		ctx := &gin.Context{}
+		RestSetRequestID(ctx, "stored")
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx.Request.Header.Set(RestRequestIDMwHeader, "header")
+
+		if id := RestGetRequestID(ctx); id != "stored" {
+			t.Errorf("(%v) when expecting (stored)", id)
+		}
+	})
+
+	t.Run("use the request id header", func(t *testing.T) {
+		ctx := &gin.Context{}
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx.Request.Header.Set(RestRequestIDMwHeader, "header")
+		ctx.Request.Header.Set(RestRequestIDMwTraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		if id := RestGetRequestID(ctx); id != "header" {
+			t.Errorf("(%v) when expecting (header)", id)
+		} else if stored, _ := ctx.Get(RestRequestIDMwContextField); stored != "header" {
+			t.Errorf("stored the (%v) id", stored)
+		}
+	})
+
+	t.Run("use the trace parent trace id", func(t *testing.T) {
+		ctx := &gin.Context{}
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx.Request.Header.Set(RestRequestIDMwTraceParentHeader, "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01")
+
+		if id := RestGetRequestID(ctx); id != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("(%v) when expecting (4bf92f3577b34da6a3ce929d0e0e4736)", id)
+		}
+	})
+
+	t.Run("generate an id on invalid headers", func(t *testing.T) {
+		scenarios := []struct {
+			header      string
+			traceParent string
+		}{
+			{ // no headers
+			},
+			{ // id with spaces and invalid trace parent version
+				header:      "invalid id",
+				traceParent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			},
+			{ // oversized id and all zero trace id
+				header:      strings.Repeat("a", RestRequestIDMwMaxLength+1),
+				traceParent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			},
+			{ // id with control characters and non hex trace id
+				header:      "id\x01",
+				traceParent: "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01",
+			},
+			{ // malformed trace parent
+				traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			ctx := &gin.Context{}
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.Header.Set(RestRequestIDMwHeader, scenario.header)
+			ctx.Request.Header.Set(RestRequestIDMwTraceParentHeader, scenario.traceParent)
+
+			id := RestGetRequestID(ctx)
+			switch {
+			case !uuid.MatchString(id):
+				t.Errorf("(%v) is not a valid generated id", id)
+			case RestGetRequestID(ctx) != id:
+				t.Error("didn't stored the generated id")
+			}
+		}
+	})
+}
+
+func Test_RestLogContext(t *testing.T) {
+	t.Run("nil context", func(t *testing.T) {
+		expected := slate.LogContext{"field": "value"}
+
+		if lctx := RestLogContext(nil, slate.LogContext{"field": "value"}); !reflect.DeepEqual(lctx, expected) {
+			t.Errorf("(%v) when expecting (%v)", lctx, expected)
+		}
+	})
+
+	t.Run("merge the contexts with the request id", func(t *testing.T) {
+		ctx := &gin.Context{}
+		RestSetRequestID(ctx, "request-id")
+		expected := slate.LogContext{"field1": "value1", "field2": "value3", RestRequestIDMwLogField: "request-id"}
+
+		lctx := RestLogContext(ctx, slate.LogContext{"field1": "value1", "field2": "value2"}, slate.LogContext{"field2": "value3"})
+		if !reflect.DeepEqual(lctx, expected) {
+			t.Errorf("(%v) when expecting (%v)", lctx, expected)
+		}
+	})
+}
+
+func Test_RestSignal(t *testing.T) {
+	t.Run("nil logger", func(t *testing.T) {
+		if e := RestSignal(&gin.Context{}, nil, "channel", slate.INFO, "message"); !errors.Is(e, slate.ErrNilPointer) {
+			t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+		}
+	})
+
+	t.Run("signal with the request id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := &gin.Context{}
+		RestSetRequestID(ctx, "request-id")
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.
+			EXPECT().
+			Signal("channel", slate.INFO, "message", slate.LogContext{"field": "value", RestRequestIDMwLogField: "request-id"}).
+			Return(nil).
+			Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		if e := RestSignal(ctx, logger, "channel", slate.INFO, "message", slate.LogContext{"field": "value"}); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		}
+	})
+}
+
+func Test_RestPropagateRequestID(t *testing.T) {
+	t.Run("nil request", func(t *testing.T) {
+		if req := RestPropagateRequestID(&gin.Context{}, nil); req != nil {
+			t.Errorf("returned the unexpected (%v) request", req)
+		}
+	})
+
+	t.Run("set the request id header", func(t *testing.T) {
+		ctx := &gin.Context{}
+		RestSetRequestID(ctx, "request-id")
+		req := &http.Request{}
+
+		if RestPropagateRequestID(ctx, req); req.Header.Get(RestRequestIDMwHeader) != "request-id" {
+			t.Errorf("(%v) when expecting (request-id)", req.Header.Get(RestRequestIDMwHeader))
+		}
+	})
+}
+
+func Test_RestRequestIDMwGenerator(t *testing.T) {
+	t.Run("NewRestRequestIDMwGenerator", func(t *testing.T) {
+		t.Run("echo the request id", func(t *testing.T) {
+			generator, e := NewRestRequestIDMwGenerator()
+			if e != nil {
+				t.Fatalf("unexpected (%v) error", e)
+			}
+
+			gin.SetMode(gin.ReleaseMode)
+			writer := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(writer)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			ctx.Request.Header.Set(RestRequestIDMwHeader, "request-id")
+			called := false
+			generator()(func(ctx *gin.Context) {
+				called = true
+				if id := RestGetRequestID(ctx); id != "request-id" {
+					t.Errorf("(%v) when expecting (request-id)", id)
+				}
+				ctx.Status(http.StatusNoContent)
+			})(ctx)
+
+			switch {
+			case !called:
+				t.Error("didn't called the next handler")
+			case writer.Header().Get(RestRequestIDMwHeader) != "request-id":
+				t.Errorf("(%v) when expecting (request-id)", writer.Header().Get(RestRequestIDMwHeader))
+			}
+		})
+
+		t.Run("echo the generated request id", func(t *testing.T) {
+			generator, _ := NewRestRequestIDMwGenerator()
+
+			gin.SetMode(gin.ReleaseMode)
+			writer := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(writer)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			generator()(nil)(ctx)
+			ctx.Status(http.StatusNoContent)
+
+			if id := writer.Header().Get(RestRequestIDMwHeader); id == "" || id != RestGetRequestID(ctx) {
+				t.Errorf("(%v) when expecting (%v)", id, RestGetRequestID(ctx))
+			}
+		})
+	})
+}
+
+func Test_RestRequestIDMwServiceRegister(t *testing.T) {
+	t.Run("NewRestRequestIDMwServiceRegister", func(t *testing.T) {
+		t.Run("create with app reference", func(t *testing.T) {
+			app := slate.NewApp()
+			if sut := NewRestRequestIDMwServiceRegister(app); sut == nil {
+				t.Error("didn't returned a valid reference")
+			} else if sut.App != app {
+				t.Error("didn't stored the app reference")
+			}
+		})
+	})
+
+	t.Run("Provide", func(t *testing.T) {
+		t.Run("nil container", func(t *testing.T) {
+			if e := NewRestRequestIDMwServiceRegister().Provide(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expected (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("retrieving the request id middleware generator", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			_ = NewRestRequestIDMwServiceRegister().Provide(container)
+
+			if sut, e := container.Get(RestRequestIDMwContainerID); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if _, ok := sut.(RestRequestIDMwGenerator); !ok {
+				t.Error("didn't returned the request id middleware generator")
+			}
+		})
+	})
+}
@@ -1,15 +1,39 @@
 package sapi
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales"
 	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pt"
+	"github.com/go-playground/locales/pt_BR"
+	"github.com/go-playground/locales/ru"
+	"github.com/go-playground/locales/zh"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
-	translations "github.com/go-playground/validator/v10/translations/en"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	estranslations "github.com/go-playground/validator/v10/translations/es"
+	frtranslations "github.com/go-playground/validator/v10/translations/fr"
+	ittranslations "github.com/go-playground/validator/v10/translations/it"
+	jatranslations "github.com/go-playground/validator/v10/translations/ja"
+	nltranslations "github.com/go-playground/validator/v10/translations/nl"
+	pttranslations "github.com/go-playground/validator/v10/translations/pt"
+	ptbrtranslations "github.com/go-playground/validator/v10/translations/pt_BR"
+	rutranslations "github.com/go-playground/validator/v10/translations/ru"
+	zhtranslations "github.com/go-playground/validator/v10/translations/zh"
 	"github.com/happyhippyhippo/slate"
 )
 
@@ -22,6 +46,11 @@ const (
 	// as the container registration id of a validation.
 	ValidationContainerID = slate.ContainerID + ".validation"
 
+	// ValidationOptionsContainerID defines the id to be used
+	// as the container registration id of a validator that accepts
+	// validation call options.
+	ValidationOptionsContainerID = ValidationContainerID + ".options"
+
 	// ValidationTranslatorContainerID defines the id to be used
 	// as the container registration id of a translator.
 	ValidationTranslatorContainerID = ValidationContainerID + ".translator"
@@ -42,8 +71,89 @@ var (
 	// ValidationLocale defines the default locale string to be used when
 	// instantiating the translator.
 	ValidationLocale = slate.EnvString(ValidationEnvID+"_LOCALE", "en")
+
+	// ValidationLocales defines the list of locales loaded into the
+	// universal translator, besides the default locale.
+	ValidationLocales = slate.EnvList(ValidationEnvID+"_LOCALES", []string{"en"})
+
+	// ValidationLocaleContextField defines the name of the gin context
+	// field used to force the locale of the validation messages.
+	ValidationLocaleContextField = slate.EnvString(ValidationEnvID+"_LOCALE_CONTEXT_FIELD", "sapi_validation_locale")
+
+	// ValidationAcceptLanguageHeader defines the name of the request
+	// header used to negotiate the locale of the validation messages.
+	ValidationAcceptLanguageHeader = slate.EnvString(ValidationEnvID+"_ACCEPT_LANGUAGE_HEADER", "Accept-Language")
+
+	// ValidationFieldTag defines the struct tag used to name the fields in
+	// the validation messages and errors.
+	ValidationFieldTag = slate.EnvString(ValidationEnvID+"_FIELD_TAG", "json")
+
+	// ValidationXMLFieldTag defines the struct tag used to name the fields
+	// in the validation messages and errors of XML negotiated requests.
+	ValidationXMLFieldTag = slate.EnvString(ValidationEnvID+"_XML_FIELD_TAG", "xml")
+
+	// ValidationConfigPathCodes defines the config path used to store the
+	// validation tags error codes that override the default ones.
+	ValidationConfigPathCodes = slate.EnvString(ValidationEnvID+"_CONFIG_PATH_CODES", "slate.api.validation.codes")
+
+	// ValidationConfigPathFallbackCode defines the config path used to
+	// store the error code assigned to the validation tags without one.
+	ValidationConfigPathFallbackCode = slate.EnvString(ValidationEnvID+"_CONFIG_PATH_FALLBACK_CODE", "slate.api.validation.fallback_code")
+
+	// ValidationFallbackCode defines the default error code assigned to
+	// the validation tags without one.
+	ValidationFallbackCode = slate.EnvInt(ValidationEnvID+"_FALLBACK_CODE", 0)
+
+	// ValidationLogChannel defines the channel id to be used when the
+	// validation parser signals a logging event.
+	ValidationLogChannel = slate.EnvString(ValidationEnvID+"_LOG_CHANNEL", "validation")
+
+	// ValidationLogUnmappedLevel defines the logging level to be used when
+	// a validation tag without error code is found.
+	ValidationLogUnmappedLevel = envToLogLevel(ValidationEnvID+"_LOG_UNMAPPED_LEVEL", slate.WARNING)
+
+	// ValidationLogUnmappedMessage defines the logging message to be used
+	// when a validation tag without error code is found.
+	ValidationLogUnmappedMessage = slate.EnvString(ValidationEnvID+"_LOG_UNMAPPED_MESSAGE", "Unmapped validation tag")
+
+	// ValidationLogCodesErrorMessage defines the logging message to be used
+	// when the configured validation error codes are invalid.
+	ValidationLogCodesErrorMessage = slate.EnvString(ValidationEnvID+"_LOG_CODES_ERROR_MESSAGE", "Invalid validation error codes")
+
+	// ValidationConfigPathMessages defines the config path used to store the
+	// validation message templates, indexed by locale and then by tag or by
+	// the field struct namespace segments and tag.
+	ValidationConfigPathMessages = slate.EnvString(ValidationEnvID+"_CONFIG_PATH_MESSAGES", "slate.api.validation.messages")
+
+	// ValidationMessageStructTag defines the struct tag used to define the
+	// validation message templates of a field, as a ";" separated list of
+	// "tag=template" entries, where the "*" tag applies to the remaining
+	// tags and a "\;" sequence defines a ";" in the template.
+	ValidationMessageStructTag = slate.EnvString(ValidationEnvID+"_MESSAGE_STRUCT_TAG", "vmsg")
+
+	// ValidationLogMessagesErrorMessage defines the logging message to be
+	// used when the configured validation message templates are invalid.
+	ValidationLogMessagesErrorMessage = slate.EnvString(ValidationEnvID+"_LOG_MESSAGES_ERROR_MESSAGE", "Invalid validation messages")
 )
 
+type validationLocale struct {
+	locale       func() locales.Translator
+	translations func(*validator.Validate, ut.Translator) error
+}
+
+var validationLocales = map[string]validationLocale{
+	"en":    {locale: en.New, translations: entranslations.RegisterDefaultTranslations},
+	"es":    {locale: es.New, translations: estranslations.RegisterDefaultTranslations},
+	"fr":    {locale: fr.New, translations: frtranslations.RegisterDefaultTranslations},
+	"it":    {locale: it.New, translations: ittranslations.RegisterDefaultTranslations},
+	"ja":    {locale: ja.New, translations: jatranslations.RegisterDefaultTranslations},
+	"nl":    {locale: nl.New, translations: nltranslations.RegisterDefaultTranslations},
+	"pt":    {locale: pt.New, translations: pttranslations.RegisterDefaultTranslations},
+	"pt_BR": {locale: pt_BR.New, translations: ptbrtranslations.RegisterDefaultTranslations},
+	"ru":    {locale: ru.New, translations: rutranslations.RegisterDefaultTranslations},
+	"zh":    {locale: zh.New, translations: zhtranslations.RegisterDefaultTranslations},
+}
+
 // ----------------------------------------------------------------------------
 // errors
 // ----------------------------------------------------------------------------
@@ -61,6 +171,92 @@ func errValidationTranslatorNotFound(
 	return slate.NewErrorFrom(ErrValidationTranslatorNotFound, translator, ctx...)
 }
 
+// ----------------------------------------------------------------------------
+// validation locale
+// ----------------------------------------------------------------------------
+
+// ValidationSetLocale will force the locale used to translate the
+// validation messages of the request.
+func ValidationSetLocale(
+	ctx *gin.Context,
+	locale string,
+) {
+	if ctx != nil {
+		ctx.Set(ValidationLocaleContextField, locale)
+	}
+}
+
+func validationLocaleList() []string {
+	list := []string{ValidationLocale}
+	for _, locale := range ValidationLocales {
+		locale = strings.TrimSpace(locale)
+		found := false
+		for _, stored := range list {
+			found = found || strings.EqualFold(stored, locale)
+		}
+		if !found && locale != "" {
+			list = append(list, locale)
+		}
+	}
+	return list
+}
+
+func validationRequestLocales(
+	ctx *gin.Context,
+) []string {
+	if ctx == nil {
+		return nil
+	}
+	// the locale forced in the context overrides the negotiated ones
+	if locale, ok := ctx.Get(ValidationLocaleContextField); ok {
+		if l, ok := locale.(string); ok && l != "" {
+			return []string{l}
+		}
+	}
+	if ctx.Request == nil {
+		return nil
+	}
+	return validationAcceptLanguage(ctx.Request.Header.Get(ValidationAcceptLanguageHeader))
+}
+
+func validationAcceptLanguage(
+	header string,
+) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	var entries []weighted
+	for _, entry := range strings.Split(header, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ";")
+		locale := strings.ReplaceAll(strings.TrimSpace(parts[0]), "-", "_")
+		if locale == "" || locale == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range parts[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, e := strconv.ParseFloat(value, 64); e == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			entries = append(entries, weighted{locale: locale, q: q})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	// the region-less language is tried right after the regional locale
+	var list []string
+	for _, entry := range entries {
+		list = append(list, entry.locale)
+		if base, _, ok := strings.Cut(entry.locale, "_"); ok {
+			list = append(list, base)
+		}
+	}
+	return list
+}
+
 // ----------------------------------------------------------------------------
 // validation universal translator
 // ----------------------------------------------------------------------------
@@ -71,6 +267,21 @@ func NewValidationUniversalTranslator() *ut.UniversalTranslator {
 	return ut.New(lang, lang)
 }
 
+// NewValidationLocalesUniversalTranslator will instantiate a universal
+// translator loaded with the configured validation locales, falling back
+// to the default validation locale.
+func NewValidationLocalesUniversalTranslator() (*ut.UniversalTranslator, error) {
+	var langs []locales.Translator
+	for _, locale := range validationLocaleList() {
+		loader, ok := validationLocales[locale]
+		if !ok {
+			return nil, errValidationTranslatorNotFound(locale)
+		}
+		langs = append(langs, loader.locale())
+	}
+	return ut.New(langs[0], langs...), nil
+}
+
 // ----------------------------------------------------------------------------
 // validation translator
 // ----------------------------------------------------------------------------
@@ -92,10 +303,21 @@ func NewValidationTranslator(
 
 // ValidationParser @todo doc
 type ValidationParser struct {
+	mutex      sync.Locker
 	mapper     map[string]int
+	overrides  map[string]int
+	fallback   int
+	reported   map[string]bool
+	messages   map[string]validationMessages
+	logger     *slate.Log
 	translator ut.Translator
 }
 
+type validationMessages struct {
+	tags   map[string]string
+	fields map[string]map[string]string
+}
+
 // NewValidationParser instantiate a new validation parser instance
 func NewValidationParser(
 	translator ut.Translator,
@@ -104,7 +326,142 @@ func NewValidationParser(
 		return nil, errNilPointer("translator")
 	}
 
+	return newValidationParser(nil, translator), nil
+}
+
+// NewValidationRulesParser instantiate a new validation parser instance
+// that maps the error codes of the given custom validation rules.
+func NewValidationRulesParser(
+	translator ut.Translator,
+	rules []ValidationRule,
+) (*ValidationParser, error) {
+	if translator == nil {
+		return nil, errNilPointer("translator")
+	}
+
+	parser := newValidationParser(nil, translator)
+	// map the custom validation rules error codes
+	for _, rule := range rules {
+		if rule != nil {
+			parser.AddError(rule.Tag(), rule.Code())
+		}
+	}
+	return parser, nil
+}
+
+// NewValidationConfigParser instantiate a new validation parser instance
+// that maps the error codes of the given custom validation rules.
+// The default validation tags error codes can be overridden by the
+// configured ones, that are reloaded on every configuration change.
+//
+// The validation messages can also be overridden by message templates,
+// that can refer to the field name as {field}, to the tag parameter as
+// {param} and to the field value as {value}. The templates are selected
+// in the following order: the configured template of the field struct
+// namespace and tag, the field struct tag template of the tag, the field
+// struct tag "*" template, and the configured template of the tag. The
+// configured templates are also used by the validators translators.
+func NewValidationConfigParser(
+	config *slate.Config,
+	logger *slate.Log,
+	translator ut.Translator,
+	rules []ValidationRule,
+) (*ValidationParser, error) {
+	// check config argument reference
+	if config == nil {
+		return nil, errNilPointer("config")
+	}
+	// check logger argument reference
+	if logger == nil {
+		return nil, errNilPointer("logger")
+	}
+	if translator == nil {
+		return nil, errNilPointer("translator")
+	}
+
+	parser := newValidationParser(logger, translator)
+	// map the custom validation rules error codes
+	for _, rule := range rules {
+		if rule != nil {
+			parser.AddError(rule.Tag(), rule.Code())
+		}
+	}
+	// retrieve the error codes overrides from the configuration
+	if config.Has(ValidationConfigPathCodes) {
+		partial, e := config.Partial(ValidationConfigPathCodes)
+		if e == nil {
+			e = parser.setCodes(partial)
+		}
+		if e != nil {
+			_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogCodesErrorMessage, slate.LogContext{"error": e})
+			return nil, e
+		}
+		// add a config observer for the error codes overrides
+		_ = config.AddObserver(ValidationConfigPathCodes, func(_ interface{}, new interface{}) {
+			partial, ok := new.(slate.ConfigPartial)
+			if !ok {
+				_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogCodesErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			if e := parser.setCodes(partial); e != nil {
+				_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogCodesErrorMessage, slate.LogContext{"error": e})
+			}
+		})
+	}
+	// retrieve the message templates from the configuration
+	if config.Has(ValidationConfigPathMessages) {
+		partial, e := config.Partial(ValidationConfigPathMessages)
+		if e == nil {
+			e = parser.setMessages(partial)
+		}
+		if e != nil {
+			_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogMessagesErrorMessage, slate.LogContext{"error": e})
+			return nil, e
+		}
+		// add a config observer for the message templates
+		_ = config.AddObserver(ValidationConfigPathMessages, func(_ interface{}, new interface{}) {
+			partial, ok := new.(slate.ConfigPartial)
+			if !ok {
+				_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogMessagesErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			if e := parser.setMessages(partial); e != nil {
+				_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogMessagesErrorMessage, slate.LogContext{"error": e})
+			}
+		})
+	}
+	// retrieve the fallback error code from the configuration
+	if config.Has(ValidationConfigPathFallbackCode) {
+		fallback, e := config.Int(ValidationConfigPathFallbackCode)
+		if e != nil {
+			_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogCodesErrorMessage, slate.LogContext{"error": e})
+			return nil, e
+		}
+		parser.setFallback(fallback)
+		// add a config observer for the fallback error code
+		_ = config.AddObserver(ValidationConfigPathFallbackCode, func(_ interface{}, new interface{}) {
+			fallback, ok := new.(int)
+			if !ok {
+				_ = logger.Signal(ValidationLogChannel, slate.ERROR, ValidationLogCodesErrorMessage, slate.LogContext{"value": new})
+				return
+			}
+			parser.setFallback(fallback)
+		})
+	}
+	return parser, nil
+}
+
+func newValidationParser(
+	logger *slate.Log,
+	translator ut.Translator,
+) *ValidationParser {
 	return &ValidationParser{
+		mutex:     &sync.Mutex{},
+		overrides: map[string]int{},
+		fallback:  ValidationFallbackCode,
+		reported:  map[string]bool{},
+		messages:  map[string]validationMessages{},
+		logger:    logger,
 		mapper: map[string]int{
 			"eqcsfield":     1,
 			"eqfield":       2,
@@ -226,9 +583,57 @@ func NewValidationParser(
 			"excluded_without":     113,
 			"excluded_without_all": 114,
 			"unique":               115,
+
+			"excluded_if":     116,
+			"excluded_unless": 117,
+			"eq_ignore_case":  118,
+			"ne_ignore_case":  119,
+			"startsnotwith":   120,
+			"endsnotwith":     121,
+			"boolean":         122,
+
+			"http_url":          123,
+			"filepath":          124,
+			"dirpath":           125,
+			"image":             126,
+			"base64rawurl":      127,
+			"eth_addr_checksum": 128,
+			"ulid":              129,
+			"jwt":               130,
+			"semver":            131,
+			"cve":               132,
+			"cron":              133,
+			"mongodb":           134,
+			"spicedb":           135,
+			"dns_rfc1035_label": 136,
+
+			"md4":       137,
+			"md5":       138,
+			"sha256":    139,
+			"sha384":    140,
+			"sha512":    141,
+			"ripemd128": 142,
+			"ripemd160": 143,
+			"tiger128":  144,
+			"tiger160":  145,
+			"tiger192":  146,
+
+			"timezone":                      147,
+			"iso3166_1_alpha2":              148,
+			"iso3166_1_alpha3":              149,
+			"iso3166_1_alpha_numeric":       150,
+			"iso3166_2":                     151,
+			"iso4217":                       152,
+			"iso4217_numeric":               153,
+			"bcp47_language_tag":            154,
+			"postcode_iso3166_alpha2":       155,
+			"postcode_iso3166_alpha2_field": 156,
+			"bic":                           157,
+			"credit_card":                   158,
+			"luhn_checksum":                 159,
 		},
 		translator: translator,
-	}, nil
+	}
 }
 
 // Parse method that will convert the list of validation error into
@@ -237,6 +642,20 @@ func (p *ValidationParser) Parse(
 	val interface{},
 	errs validator.ValidationErrors,
 ) (*Envelope, error) {
+	return p.ParseTranslated(p.translator, val, errs)
+}
+
+// ParseTranslated method that will convert the list of validation error
+// into an envelope struct with the messages translated by the given
+// translator.
+func (p *ValidationParser) ParseTranslated(
+	translator ut.Translator,
+	val interface{},
+	errs validator.ValidationErrors,
+) (*Envelope, error) {
+	if translator == nil {
+		return nil, errNilPointer("translator")
+	}
 	if val == nil {
 		return nil, errNilPointer("value")
 	}
@@ -247,7 +666,7 @@ func (p *ValidationParser) Parse(
 
 	resp := NewEnvelope(http.StatusBadRequest, nil, nil)
 	for _, err := range errs {
-		parsed, e := p.convert(val, err)
+		parsed, e := p.convert(translator, val, err)
 		if e != nil {
 			return nil, e
 		}
@@ -262,10 +681,95 @@ func (p *ValidationParser) AddError(
 	e string,
 	code int,
 ) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 	p.mapper[e] = code
 }
 
+// Code will retrieve the error code of a validation tag, giving
+// precedence to the configured codes. The tags without code are
+// signaled once and assigned the fallback code.
+func (p *ValidationParser) Code(
+	tag string,
+) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if code, ok := p.overrides[tag]; ok {
+		return code
+	}
+	if code, ok := p.mapper[tag]; ok {
+		return code
+	}
+	if !p.reported[tag] && p.logger != nil {
+		p.reported[tag] = true
+		_ = p.logger.Signal(ValidationLogChannel, ValidationLogUnmappedLevel, ValidationLogUnmappedMessage, slate.LogContext{
+			"tag":  tag,
+			"code": p.fallback,
+		})
+	}
+	return p.fallback
+}
+
+func (p *ValidationParser) setCodes(
+	partial slate.ConfigPartial,
+) error {
+	// retrieve the configured tags error codes
+	overrides := map[string]int{}
+	for _, tag := range partial.Entries() {
+		code, e := partial.Int(tag)
+		if e != nil {
+			return e
+		}
+		overrides[tag] = code
+	}
+	// store the codes and signal again the unmapped tags
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.overrides = overrides
+	p.reported = map[string]bool{}
+	return nil
+}
+
+func (p *ValidationParser) setMessages(
+	partial slate.ConfigPartial,
+) error {
+	// retrieve the configured templates of every locale, where the
+	// string entries are tag templates and the partial entries are
+	// the field struct namespace segments
+	messages := map[string]validationMessages{}
+	for _, locale := range partial.Entries() {
+		entries, e := partial.Partial(locale)
+		if e != nil {
+			return e
+		}
+		loaded := validationMessages{
+			tags:   map[string]string{},
+			fields: map[string]map[string]string{},
+		}
+		if e := loaded.load(entries, ""); e != nil {
+			return e
+		}
+		messages[locale] = loaded
+	}
+	// store the loaded templates
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.messages = messages
+	return nil
+}
+
+func (p *ValidationParser) setFallback(
+	fallback int,
+) {
+	// store the code and signal again the unmapped tags
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.fallback = fallback
+	p.reported = map[string]bool{}
+}
+
 func (p *ValidationParser) convert(
+	translator ut.Translator,
 	value interface{},
 	e validator.FieldError,
 ) (*EnvelopeStatusError, error) {
@@ -273,17 +777,301 @@ func (p *ValidationParser) convert(
 		return nil, errNilPointer("error")
 	}
 
+	// split the error namespaces, discarding the root struct type name
 	typeof := reflect.TypeOf(value)
-	field, _ := typeof.FieldByName(e.StructField())
+	for typeof.Kind() == reflect.Pointer {
+		typeof = typeof.Elem()
+	}
+	fields := validationNamespace(e.StructNamespace())
+	path := validationNamespace(e.Namespace())
+	if typeof.Name() != "" && len(fields) > 1 && len(path) > 1 {
+		fields = fields[1:]
+		path = path[1:]
+	}
+	// retrieve the param of the nested field related to the error
 	iparam := 0
-	if param, ok := field.Tag.Lookup("vparam"); ok {
-		var err error
-		if iparam, err = strconv.Atoi(param); err != nil {
-			return nil, err
+	field, found := validationField(typeof, fields)
+	if found {
+		if param, ok := field.Tag.Lookup("vparam"); ok {
+			var err error
+			if iparam, err = strconv.Atoi(param); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tag := e.Tag()
+	name := e.Field()
+	return NewEnvelopeStatusError(p.Code(tag), p.message(translator, e, tag, name, field)).
+		SetParam(iparam).
+		SetField(name).
+		SetPath(validationPath(path)), nil
+}
+
+func (p *ValidationParser) message(
+	translator ut.Translator,
+	e validator.FieldError,
+	tag string,
+	name string,
+	field reflect.StructField,
+) string {
+	// select the most specific template of the field and tag
+	messages := p.templates(translator)
+	template, ok := messages.field(e)
+	if !ok {
+		template, ok = validationFieldMessage(field, tag)
+	}
+	if !ok {
+		template, ok = messages.tags[tag]
+	}
+	if !ok {
+		return e.Translate(translator)
+	}
+	return validationMessageRender(template, name, e)
+}
+
+func (p *ValidationParser) templates(
+	translator ut.Translator,
+) validationMessages {
+	// retrieve the configured templates of the translator locale
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.messages) == 0 {
+		return validationMessages{}
+	}
+	return p.messages[translator.Locale()]
+}
+
+func (p *ValidationParser) tags() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	tags := make([]string, 0, len(p.mapper)+len(p.overrides))
+	for tag := range p.mapper {
+		tags = append(tags, tag)
+	}
+	for tag := range p.overrides {
+		if _, ok := p.mapper[tag]; !ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func (p *ValidationParser) translation(
+	defaults ut.Translator,
+) validator.TranslationFunc {
+	return func(translator ut.Translator, e validator.FieldError) string {
+		// select the configured template of the field and tag, or use
+		// the default translation of the tag
+		messages := p.templates(translator)
+		template, ok := messages.field(e)
+		if !ok {
+			template, ok = messages.tags[e.Tag()]
+		}
+		if !ok {
+			return e.Translate(defaults)
+		}
+		return validationMessageRender(template, e.Field(), e)
+	}
+}
+
+func (m validationMessages) field(
+	e validator.FieldError,
+) (string, bool) {
+	if len(m.fields) == 0 {
+		return "", false
+	}
+	template, ok := m.fields[validationMessagePath(e.StructNamespace())][e.Tag()]
+	return template, ok
+}
+
+func (m validationMessages) load(
+	partial slate.ConfigPartial,
+	path string,
+) error {
+	for _, key := range partial.Entries() {
+		// step into the partial entries as the nested field segments
+		value, _ := partial.Get(key)
+		if nested, ok := value.(slate.ConfigPartial); ok {
+			if e := m.load(nested, validationWalkerJoin(path, strings.ToLower(key))); e != nil {
+				return e
+			}
+			continue
+		}
+		template, e := partial.String(key)
+		if e != nil {
+			return e
+		}
+		if path == "" {
+			m.tags[key] = template
+			continue
+		}
+		if _, ok := m.fields[path]; !ok {
+			m.fields[path] = map[string]string{}
+		}
+		m.fields[path][key] = template
+	}
+	return nil
+}
+
+func validationMessagePath(
+	namespace string,
+) string {
+	// discard the index segments, and lower the case of the field names
+	// as the config loaded keys
+	var fields []string
+	for _, segment := range validationNamespace(namespace) {
+		if !strings.HasPrefix(segment, "[") {
+			fields = append(fields, strings.ToLower(segment))
+		}
+	}
+	return strings.Join(fields, ".")
+}
+
+func validationMessageRender(
+	template string,
+	name string,
+	e validator.FieldError,
+) string {
+	return strings.NewReplacer(
+		"{field}", name,
+		"{param}", e.Param(),
+		"{value}", fmt.Sprintf("%v", e.Value()),
+	).Replace(template)
+}
+
+func validationFieldMessage(
+	field reflect.StructField,
+	tag string,
+) (string, bool) {
+	list, ok := field.Tag.Lookup(ValidationMessageStructTag)
+	if !ok {
+		return "", false
+	}
+	// search for the tag template, or the "*" default template
+	template, found := "", false
+	for _, entry := range validationMessageEntries(list) {
+		prefix, tagged, ok := strings.Cut(entry, "=")
+		switch prefix = strings.TrimSpace(prefix); {
+		case !ok:
+			continue
+		case prefix == tag:
+			return strings.TrimSpace(tagged), true
+		case prefix == "*":
+			template, found = strings.TrimSpace(tagged), true
 		}
 	}
+	return template, found
+}
 
-	return NewEnvelopeStatusError(p.mapper[e.Tag()], e.Translate(p.translator)).SetParam(iparam), nil
+func validationMessageEntries(
+	list string,
+) []string {
+	// split the list by the unescaped ";" separators
+	var entries []string
+	current := strings.Builder{}
+	escaped := false
+	for _, r := range list {
+		switch {
+		case escaped:
+			if r != ';' && r != '\\' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			entries = append(entries, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	return append(entries, current.String())
+}
+
+func validationNamespace(
+	namespace string,
+) []string {
+	// split the namespace into field names and "[key]" index segments
+	var segments []string
+	current := strings.Builder{}
+	flush := func() {
+		if current.Len() != 0 {
+			segments = append(segments, current.String())
+			current.Reset()
+		}
+	}
+	depth := 0
+	for _, r := range namespace {
+		switch {
+		case r == '[' && depth == 0:
+			flush()
+			depth++
+			current.WriteRune(r)
+		case r == ']' && depth == 1:
+			depth--
+			current.WriteRune(r)
+			flush()
+		case r == '.' && depth == 0:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return segments
+}
+
+func validationField(
+	typeof reflect.Type,
+	segments []string,
+) (reflect.StructField, bool) {
+	// the field is only reported as found if the full path resolves
+	var field reflect.StructField
+	found := false
+	for _, segment := range segments {
+		for typeof.Kind() == reflect.Pointer {
+			typeof = typeof.Elem()
+		}
+		if strings.HasPrefix(segment, "[") {
+			// step into the element type of the indexed container
+			switch typeof.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				typeof = typeof.Elem()
+			default:
+				return reflect.StructField{}, false
+			}
+			continue
+		}
+		if typeof.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+		nested, ok := typeof.FieldByName(segment)
+		if !ok {
+			return reflect.StructField{}, false
+		}
+		field, found = nested, true
+		typeof = nested.Type
+	}
+	return field, found
+}
+
+func validationPath(
+	segments []string,
+) string {
+	path := strings.Builder{}
+	for _, segment := range segments {
+		if path.Len() != 0 && !strings.HasPrefix(segment, "[") {
+			path.WriteRune('.')
+		}
+		path.WriteString(segment)
+	}
+	return path.String()
 }
 
 // ----------------------------------------------------------------------------
@@ -295,6 +1083,105 @@ func (p *ValidationParser) convert(
 // an initialized response envelope with the founded error
 type Validator func(val interface{}) (*Envelope, error)
 
+// ValidatorWithOptions is a function type used to define a calling
+// interface of function responsible to validate an instance of a
+// structure, tuned by the given validation call options, and return an
+// initialized response envelope with the founded error
+type ValidatorWithOptions func(val interface{}, opts ...ValidationOption) (*Envelope, error)
+
+// Validator will retrieve a validation function that validates the
+// given value without any validation call options.
+func (v ValidatorWithOptions) Validator() Validator {
+	return func(val interface{}) (*Envelope, error) {
+		return v(val)
+	}
+}
+
+// ValidationOption defines a function used to tune a single validation call.
+type ValidationOption func(*validationOptions)
+
+type validationOptions struct {
+	ctx      *gin.Context
+	locale   string
+	tag      string
+	partial  bool
+	body     []byte
+	failures validator.ValidationErrors
+}
+
+func newValidationOptions(
+	opts []ValidationOption,
+) validationOptions {
+	options := validationOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func (o validationOptions) fieldTag() string {
+	if o.tag != "" {
+		return o.tag
+	}
+	return validationRequestFieldTag(o.ctx)
+}
+
+func (o validationOptions) translator(
+	translator ut.Translator,
+	universalTranslator *ut.UniversalTranslator,
+) ut.Translator {
+	if universalTranslator == nil {
+		return translator
+	}
+	candidates := validationRequestLocales(o.ctx)
+	if o.locale != "" {
+		candidates = []string{o.locale}
+	}
+	if found, ok := universalTranslator.FindTranslator(candidates...); ok {
+		return found
+	}
+	return translator
+}
+
+// ValidationWithContext will select the validation messages locale from
+// the given request context, by the forced context locale or by the
+// request Accept-Language header, and the fields names by the request
+// negotiated response format.
+func ValidationWithContext(
+	ctx *gin.Context,
+) ValidationOption {
+	return func(opts *validationOptions) {
+		opts.ctx = ctx
+	}
+}
+
+// ValidationWithFieldTag will force the struct tag used to name the
+// fields in the validation messages, like "json" or "xml".
+func ValidationWithFieldTag(
+	tag string,
+) ValidationOption {
+	return func(opts *validationOptions) {
+		opts.tag = tag
+	}
+}
+
+// ValidationWithLocale will force the validation messages locale.
+func ValidationWithLocale(
+	locale string,
+) ValidationOption {
+	return func(opts *validationOptions) {
+		opts.locale = locale
+	}
+}
+
+func validationWithFailures(
+	failures validator.ValidationErrors,
+) ValidationOption {
+	return func(opts *validationOptions) {
+		opts.failures = failures
+	}
+}
+
 // NewValidator instantiates a new validation function
 func NewValidator(
 	translator ut.Translator,
@@ -308,24 +1195,296 @@ func NewValidator(
 	if parser == nil {
 		return nil, errNilPointer("parser")
 	}
-	// register the translator in the used validator
-	validate := validator.New()
-	_ = translations.RegisterDefaultTranslations(validate, translator)
+	validate, e := newValidator(translator, parser, nil, nil, nil)
+	if e != nil {
+		return nil, e
+	}
+	return validate.Validator(), nil
+}
+
+// NewValidatorWithLocales instantiates a new validation function that
+// translates the messages to the loaded locales of the given universal
+// translator, selected by the validation call options.
+func NewValidatorWithLocales(
+	translator ut.Translator,
+	parser *ValidationParser,
+	universalTranslator *ut.UniversalTranslator,
+) (ValidatorWithOptions, error) {
+	// check validate argument reference
+	if translator == nil {
+		return nil, errNilPointer("translator")
+	}
+	// check parser argument reference
+	if parser == nil {
+		return nil, errNilPointer("parser")
+	}
+	// check universal translator argument reference
+	if universalTranslator == nil {
+		return nil, errNilPointer("universalTranslator")
+	}
+	return newValidator(translator, parser, universalTranslator, nil, nil)
+}
+
+// NewValidatorWithRules instantiates a new validation function that
+// translates the messages to the loaded locales of the given universal
+// translator, and registers the given custom and struct level rules.
+func NewValidatorWithRules(
+	translator ut.Translator,
+	parser *ValidationParser,
+	universalTranslator *ut.UniversalTranslator,
+	rules []ValidationRule,
+	structRules []ValidationStructRule,
+) (ValidatorWithOptions, error) {
+	// check validate argument reference
+	if translator == nil {
+		return nil, errNilPointer("translator")
+	}
+	// check parser argument reference
+	if parser == nil {
+		return nil, errNilPointer("parser")
+	}
+	// check universal translator argument reference
+	if universalTranslator == nil {
+		return nil, errNilPointer("universalTranslator")
+	}
+	return newValidator(translator, parser, universalTranslator, rules, structRules)
+}
+
+func newValidator(
+	translator ut.Translator,
+	parser *ValidationParser,
+	universalTranslator *ut.UniversalTranslator,
+	rules []ValidationRule,
+	structRules []ValidationStructRule,
+) (ValidatorWithOptions, error) {
+	// collect the loaded locales translators and translations registrations
+	loader, ok := validationLocales[ValidationLocale]
+	if !ok {
+		loader = validationLocales["en"]
+	}
+	localeTranslators := []validationLocaleTranslator{{translator: translator, locale: loader}}
+	for _, locale := range validationLocaleList() {
+		if universalTranslator == nil {
+			break
+		}
+		trans, found := universalTranslator.GetTranslator(locale)
+		loader, ok := validationLocales[locale]
+		if !found || !ok || trans == translator {
+			continue
+		}
+		localeTranslators = append(localeTranslators, validationLocaleTranslator{translator: trans, locale: loader})
+	}
+	// collect the tags that can have message templates
+	tags := parser.tags()
+	for _, rule := range rules {
+		if rule != nil {
+			tags = append(tags, rule.Tag())
+		}
+	}
+	// create a validator for the default field names tag, and another one
+	// for the xml field names with its own locale translators, as the
+	// default messages can only be registered once in a translator
+	validates := map[string]*validationInstance{}
+	for _, tag := range []string{ValidationFieldTag, ValidationXMLFieldTag} {
+		if _, ok := validates[tag]; ok {
+			continue
+		}
+		instance := newValidationInstance(tag, structRules)
+		var translators, defaults []ut.Translator
+		for _, lt := range localeTranslators {
+			// the default field names validator uses the given translators,
+			// unless they already hold the messages of another validator
+			trans := lt.translator
+			if len(validates) != 0 {
+				trans = ut.New(lt.locale.locale()).GetFallback()
+			}
+			shadow := &validationShadowTranslator{Translator: trans}
+			e := lt.locale.translations(instance.validate, shadow)
+			var conflict *ut.ErrConflictingTranslation
+			if errors.As(e, &conflict) && trans == lt.translator {
+				trans = ut.New(lt.locale.locale()).GetFallback()
+				shadow = &validationShadowTranslator{Translator: trans}
+				e = lt.locale.translations(instance.validate, shadow)
+			}
+			if e != nil {
+				return nil, e
+			}
+			instance.translators[lt.translator] = trans
+			translators = append(translators, trans)
+			defaults = append(defaults, shadow)
+		}
+		// register the custom validation rules
+		if e := validationRegisterRules(instance.validate, defaults, rules); e != nil {
+			return nil, e
+		}
+		// register the message templates translations
+		if e := validationRegisterTemplates(instance.validate, parser, tags, translators, defaults); e != nil {
+			return nil, e
+		}
+		validates[tag] = instance
+	}
 	// return the validation method instance
-	return func(value interface{}) (*Envelope, error) {
+	return func(value interface{}, opts ...ValidationOption) (*Envelope, error) {
 		// check the value argument reference
 		if value == nil {
 			return nil, errNilPointer("value")
 		}
-		// validate the given structure
-		if errs := validate.Struct(value); errs != nil {
+		// select the validator of the requested field names tag
+		options := newValidationOptions(opts)
+		instance, ok := validates[options.fieldTag()]
+		if !ok {
+			instance = validates[ValidationFieldTag]
+		}
+		// validate the given structure, or compose the envelope of the
+		// given failures of an already validated structure
+		var errs error
+		if len(options.failures) != 0 {
+			errs = options.failures
+		} else {
+			// restrict the validated fields on a partial validation
+			filter, e := options.filter(value)
+			if e != nil {
+				return nil, e
+			}
+			errs = instance.check(value, filter)
+		}
+		if errs != nil {
+			// select the translator of the requested locale
+			trans := options.translator(translator, universalTranslator)
 			// compose the response envelope with the parsed validation error
-			return parser.Parse(value, errs.(validator.ValidationErrors))
+			list, ok := errs.(validator.ValidationErrors)
+			if !ok {
+				return nil, errs
+			}
+			return parser.ParseTranslated(instance.translator(trans), value, list)
 		}
 		return nil, nil
 	}, nil
 }
 
+type validationLocaleTranslator struct {
+	translator ut.Translator
+	locale     validationLocale
+}
+
+// validationShadowTranslator is a translator that shares the messages of
+// the shadowed translator, used to hold the default translations of the
+// tags that are translated by the message templates.
+type validationShadowTranslator struct {
+	ut.Translator
+}
+
+func validationRegisterTemplates(
+	validate *validator.Validate,
+	parser *ValidationParser,
+	tags []string,
+	translators []ut.Translator,
+	defaults []ut.Translator,
+) error {
+	// register the tags translation by the message templates on every
+	// translator, falling back to the tag default translation
+	for i, translator := range translators {
+		translation := parser.translation(defaults[i])
+		for _, tag := range tags {
+			if e := validate.RegisterTranslation(tag, translator, validationNoRegistration, translation); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func validationNoRegistration(
+	ut.Translator,
+) error {
+	return nil
+}
+
+type validationInstance struct {
+	validate    *validator.Validate
+	tagName     func(reflect.StructField) string
+	registered  map[reflect.Type]bool
+	translators map[ut.Translator]ut.Translator
+}
+
+func newValidationInstance(
+	tag string,
+	structRules []ValidationStructRule,
+) *validationInstance {
+	validate := validator.New()
+	tagName := validationTagName(tag)
+	validate.RegisterTagNameFunc(tagName)
+	return &validationInstance{
+		validate:    validate,
+		tagName:     tagName,
+		registered:  validationRegisterStructRules(validate, tagName, structRules),
+		translators: map[ut.Translator]ut.Translator{},
+	}
+}
+
+func (i *validationInstance) check(
+	value interface{},
+	filter validator.FilterFunc,
+) error {
+	// validate the structure tags and registered struct level rules
+	var e error
+	if filter != nil {
+		e = i.validate.StructFiltered(value, filter)
+	} else {
+		e = i.validate.Struct(value)
+	}
+	errs, ok := e.(validator.ValidationErrors)
+	if e != nil && !ok {
+		return e
+	}
+	// run the struct level validation of the structures that implement
+	// the struct validator interface
+	errs = append(errs, validationStructValidate(i.validate, value, i.tagName, filter, i.registered)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (i *validationInstance) translator(
+	translator ut.Translator,
+) ut.Translator {
+	if own, ok := i.translators[translator]; ok {
+		return own
+	}
+	return translator
+}
+
+func validationTagName(
+	tag string,
+) func(reflect.StructField) string {
+	return func(field reflect.StructField) string {
+		// discard the tag options and the xml parent elements
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if index := strings.LastIndex(name, ">"); index != -1 {
+			name = name[index+1:]
+		}
+		if name == "-" {
+			return ""
+		}
+		return name
+	}
+}
+
+func validationRequestFieldTag(
+	ctx *gin.Context,
+) string {
+	if ctx == nil || ctx.Request == nil || ctx.Request.Header.Get("Accept") == "" {
+		return ValidationFieldTag
+	}
+	switch ctx.NegotiateFormat(gin.MIMEJSON, gin.MIMEXML, gin.MIMEXML2) {
+	case gin.MIMEXML, gin.MIMEXML2:
+		return ValidationXMLFieldTag
+	default:
+		return ValidationFieldTag
+	}
+}
+
 // ----------------------------------------------------------------------------
 // validation service register
 // ----------------------------------------------------------------------------
@@ -355,9 +1514,68 @@ func (sr ValidationServiceRegister) Provide(
 	if container == nil {
 		return errNilPointer("container")
 	}
-	_ = container.Add(ValidationUniversalTranslatorContainerID, NewValidationUniversalTranslator)
+	_ = container.Add(ValidationAllRulesContainerID, sr.getRules(container))
+	_ = container.Add(ValidationAllStructRulesContainerID, sr.getStructRules(container))
+	_ = container.Add(ValidationAllAsyncRulesContainerID, sr.getAsyncRules(container))
+	_ = container.Add(ValidationUniversalTranslatorContainerID, NewValidationLocalesUniversalTranslator)
 	_ = container.Add(ValidationTranslatorContainerID, NewValidationTranslator)
-	_ = container.Add(ValidationParserContainerID, NewValidationParser)
-	_ = container.Add(ValidationContainerID, NewValidator)
+	_ = container.Add(ValidationParserContainerID, NewValidationConfigParser)
+	_ = container.Add(ValidationOptionsContainerID, NewValidatorWithRules)
+	_ = container.Add(ValidationContainerID, ValidatorWithOptions.Validator)
+	_ = container.Add(ValidationAsyncContainerID, NewValidatorCtx)
 	return nil
 }
+
+func (ValidationServiceRegister) getRules(
+	container *slate.ServiceContainer,
+) func() []ValidationRule {
+	return func() []ValidationRule {
+		// retrieve all the custom validation rules
+		var rules []ValidationRule
+		entries, _ := container.Tag(ValidationRuleTag)
+		for _, entry := range entries {
+			// type check the retrieved service
+			rule, ok := entry.(ValidationRule)
+			if ok {
+				rules = append(rules, rule)
+			}
+		}
+		return rules
+	}
+}
+
+func (ValidationServiceRegister) getStructRules(
+	container *slate.ServiceContainer,
+) func() []ValidationStructRule {
+	return func() []ValidationStructRule {
+		// retrieve all the struct level validation rules
+		var rules []ValidationStructRule
+		entries, _ := container.Tag(ValidationStructRuleTag)
+		for _, entry := range entries {
+			// type check the retrieved service
+			rule, ok := entry.(ValidationStructRule)
+			if ok {
+				rules = append(rules, rule)
+			}
+		}
+		return rules
+	}
+}
+
+func (ValidationServiceRegister) getAsyncRules(
+	container *slate.ServiceContainer,
+) func() []ValidationAsyncRule {
+	return func() []ValidationAsyncRule {
+		// retrieve all the asynchronous validation rules
+		var rules []ValidationAsyncRule
+		entries, _ := container.Tag(ValidationAsyncRuleTag)
+		for _, entry := range entries {
+			// type check the retrieved service
+			rule, ok := entry.(ValidationAsyncRule)
+			if ok {
+				rules = append(rules, rule)
+			}
+		}
+		return rules
+	}
+}
@@ -0,0 +1,303 @@
+package sapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// ValidationAsyncContainerID defines the id to be used as the
+	// container registration id of the context-aware validator.
+	ValidationAsyncContainerID = ValidationContainerID + ".async"
+
+	// ValidationAsyncRuleTag defines the tag to be assigned to all the
+	// asynchronous validation rules registered in the application container.
+	ValidationAsyncRuleTag = ValidationAsyncContainerID + ".rule"
+
+	// ValidationAllAsyncRulesContainerID defines the id to be used as the
+	// container registration id of the list of all asynchronous rules.
+	ValidationAllAsyncRulesContainerID = ValidationAsyncRuleTag + ".all"
+)
+
+var (
+	// ValidationAsyncStructTag defines the struct tag used to list the
+	// asynchronous rules of a field, as a comma separated list of rule
+	// tags with an optional "=param" suffix.
+	ValidationAsyncStructTag = slate.EnvString(ValidationEnvID+"_ASYNC_STRUCT_TAG", "vasync")
+
+	// ValidationAsyncTimeout defines the maximum time in milliseconds that
+	// the asynchronous rules of a validation can take.
+	ValidationAsyncTimeout = slate.EnvInt(ValidationEnvID+"_ASYNC_TIMEOUT", 5000)
+
+	// ValidationAsyncConcurrency defines the maximum number of asynchronous
+	// rules checks executed at the same time by a validation.
+	ValidationAsyncConcurrency = slate.EnvInt(ValidationEnvID+"_ASYNC_CONCURRENCY", 4)
+
+	// ValidationAsyncErrorCode defines the envelope error code used to
+	// report asynchronous rules checks that failed or timed out.
+	ValidationAsyncErrorCode = slate.EnvInt(ValidationEnvID+"_ASYNC_ERROR_CODE", 998)
+
+	// ValidationAsyncErrorMessage defines the envelope error message used
+	// to report asynchronous rules checks that failed or timed out.
+	ValidationAsyncErrorMessage = slate.EnvString(ValidationEnvID+"_ASYNC_ERROR_MESSAGE", "validation unavailable")
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrUnknownValidationAsyncRule defines an error that denotes a
+	// field referring to an asynchronous rule that is not registered.
+	ErrUnknownValidationAsyncRule = fmt.Errorf("unknown validation async rule")
+)
+
+func errUnknownValidationAsyncRule(
+	tag string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrUnknownValidationAsyncRule, tag, ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// validation async rule
+// ----------------------------------------------------------------------------
+
+// ValidationAsyncRule defines the interface of a validation rule that
+// needs I/O to check a field value, like a uniqueness check. The error
+// code and message of the rule tag are the ones defined by the validation
+// rule registered with the same tag.
+type ValidationAsyncRule interface {
+	Tag() string
+	Check(ctx context.Context, value interface{}, param string) (bool, error)
+}
+
+type validationAsyncRule struct {
+	tag   string
+	check func(ctx context.Context, value interface{}, param string) (bool, error)
+}
+
+var _ ValidationAsyncRule = &validationAsyncRule{}
+
+// NewValidationAsyncRule will instantiate a simple asynchronous rule.
+func NewValidationAsyncRule(
+	tag string,
+	check func(ctx context.Context, value interface{}, param string) (bool, error),
+) (ValidationAsyncRule, error) {
+	// check the check function argument reference
+	if check == nil {
+		return nil, errNilPointer("check")
+	}
+	return &validationAsyncRule{
+		tag:   tag,
+		check: check,
+	}, nil
+}
+
+// Tag retrieves the rule validation tag.
+func (r validationAsyncRule) Tag() string {
+	return r.tag
+}
+
+// Check will verify the given field value.
+func (r validationAsyncRule) Check(
+	ctx context.Context,
+	value interface{},
+	param string,
+) (bool, error) {
+	return r.check(ctx, value, param)
+}
+
+// ----------------------------------------------------------------------------
+// validator ctx
+// ----------------------------------------------------------------------------
+
+// ValidatorCtx is a function type used to define a context-aware
+// validation function that, after the synchronous validation of the
+// structure, runs the asynchronous rules of its fields. If a rule check
+// returns an error, or the checks don't end within the configured
+// timeout, the validation returns a service unavailable envelope.
+type ValidatorCtx func(ctx context.Context, val interface{}, opts ...ValidationOption) (*Envelope, error)
+
+// NewValidatorCtx instantiates a new context-aware validation function.
+func NewValidatorCtx(
+	translator ut.Translator,
+	parser *ValidationParser,
+	universalTranslator *ut.UniversalTranslator,
+	validate ValidatorWithOptions,
+	rules []ValidationAsyncRule,
+) (ValidatorCtx, error) {
+	// check the arguments references
+	if translator == nil {
+		return nil, errNilPointer("translator")
+	}
+	if parser == nil {
+		return nil, errNilPointer("parser")
+	}
+	if universalTranslator == nil {
+		return nil, errNilPointer("universalTranslator")
+	}
+	if validate == nil {
+		return nil, errNilPointer("validate")
+	}
+	// index the asynchronous rules by tag
+	registry := map[string]ValidationAsyncRule{}
+	for _, rule := range rules {
+		if rule != nil {
+			registry[rule.Tag()] = rule
+		}
+	}
+	// return the validation method instance
+	return func(ctx context.Context, value interface{}, opts ...ValidationOption) (*Envelope, error) {
+		// check the context argument reference
+		if ctx == nil {
+			return nil, errNilPointer("ctx")
+		}
+		// run the asynchronous rules only on synchronously valid values
+		if env, e := validate(value, opts...); e != nil || env != nil {
+			return env, e
+		}
+		options := newValidationOptions(opts)
+		filter, e := options.filter(value)
+		if e != nil {
+			return nil, e
+		}
+		checks, e := validationAsyncChecks(value, validationTagName(options.fieldTag()), filter, registry)
+		if e != nil || len(checks) == 0 {
+			return nil, e
+		}
+		// run the checks with the configured timeout and concurrency
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(ValidationAsyncTimeout)*time.Millisecond)
+		defer cancel()
+		valid, e := validationAsyncRun(ctx, checks)
+		if e != nil {
+			return NewEnvelope(http.StatusServiceUnavailable, nil).
+				AddError(NewEnvelopeStatusError(ValidationAsyncErrorCode, ValidationAsyncErrorMessage)), nil
+		}
+		// compose the envelope with the failed checks
+		var failures validator.ValidationErrors
+		for i, check := range checks {
+			if !valid[i] {
+				failures = append(failures, check.fieldError)
+			}
+		}
+		if len(failures) == 0 {
+			return nil, nil
+		}
+		// let the validator translate the failures with the messages of its
+		// registered rules, if it's able to
+		if env, e := validate(value, append(opts, validationWithFailures(failures))...); e != nil || env != nil {
+			return env, e
+		}
+		return parser.ParseTranslated(options.translator(translator, universalTranslator), value, failures)
+	}, nil
+}
+
+type validationAsyncCheck struct {
+	rule       ValidationAsyncRule
+	fieldError *validationFieldError
+}
+
+type validationAsyncResult struct {
+	index int
+	valid bool
+	err   error
+}
+
+func validationAsyncChecks(
+	value interface{},
+	tagName func(reflect.StructField) string,
+	filter validator.FilterFunc,
+	registry map[string]ValidationAsyncRule,
+) ([]*validationAsyncCheck, error) {
+	var checks []*validationAsyncCheck
+	walker := newValidationWalker(tagName, filter)
+	e := walker.walk(value, func(_, current reflect.Value, ns, structNs string) error {
+		return walker.fields(current, ns, structNs, func(i int, field reflect.StructField, fieldNs, fieldStructNs string) error {
+			// register the field listed asynchronous rules checks
+			list, ok := field.Tag.Lookup(ValidationAsyncStructTag)
+			if !ok {
+				return nil
+			}
+			for _, entry := range strings.Split(list, ",") {
+				tag, param, _ := strings.Cut(strings.TrimSpace(entry), "=")
+				if tag == "" {
+					continue
+				}
+				rule, ok := registry[tag]
+				if !ok {
+					return errUnknownValidationAsyncRule(tag)
+				}
+				checks = append(checks, &validationAsyncCheck{
+					rule: rule,
+					fieldError: &validationFieldError{
+						tag:             tag,
+						param:           param,
+						field:           walker.name(field),
+						structField:     field.Name,
+						namespace:       fieldNs,
+						structNamespace: fieldStructNs,
+						value:           current.Field(i).Interface(),
+						typeof:          field.Type,
+					},
+				})
+			}
+			return nil
+		})
+	})
+	return checks, e
+}
+
+func validationAsyncRun(
+	ctx context.Context,
+	checks []*validationAsyncCheck,
+) ([]bool, error) {
+	concurrency := ValidationAsyncConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	semaphore := make(chan struct{}, concurrency)
+	// the results channel can hold all the results, so the checks that
+	// ignore the context termination never block after the run returned
+	results := make(chan validationAsyncResult, len(checks))
+	valid := make([]bool, len(checks))
+	started, pending := 0, 0
+	for started < len(checks) || pending > 0 {
+		// only wait for a free slot while there are checks to start
+		slots := semaphore
+		if started == len(checks) {
+			slots = nil
+		}
+		select {
+		case slots <- struct{}{}:
+			go func(index int, check *validationAsyncCheck) {
+				defer func() { <-semaphore }()
+				ok, e := check.rule.Check(ctx, check.fieldError.value, check.fieldError.param)
+				results <- validationAsyncResult{index: index, valid: ok, err: e}
+			}(started, checks[started])
+			started++
+			pending++
+		case result := <-results:
+			pending--
+			if result.err != nil {
+				return nil, result.err
+			}
+			valid[result.index] = result.valid
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return valid, nil
+}
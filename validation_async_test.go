@@ -0,0 +1,407 @@
+package sapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/happyhippyhippo/slate"
+)
+
+type validationTestStore struct {
+	mutex  sync.Mutex
+	emails map[string]bool
+	ids    map[string]bool
+}
+
+func (s *validationTestStore) has(
+	ctx context.Context,
+	list map[string]bool,
+	value interface{},
+) (bool, error) {
+	if e := ctx.Err(); e != nil {
+		return false, e
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return list[fmt.Sprintf("%v", value)], nil
+}
+
+type validationTestAccount struct {
+	Email   string `json:"email" xml:"mail" validate:"required" vasync:"unique_email" vparam:"1"`
+	Team    string `json:"team" vasync:"exists=teams" vparam:"2"`
+	Comment string `json:"comment"`
+}
+
+type validationTestSignup struct {
+	Email string `json:"email" vasync:"unique_email" vparam:"1"`
+}
+
+type validationTestAccountNode struct {
+	Account validationTestAccount      `json:"account"`
+	Next    *validationTestAccountNode `json:"next" validate:"-"`
+}
+
+type validationTestAccounts struct {
+	Accounts []validationTestAccount `json:"accounts" validate:"dive"`
+}
+
+func Test_ValidationAsyncRule(t *testing.T) {
+	t.Run("NewValidationAsyncRule", func(t *testing.T) {
+		t.Run("nil check function", func(t *testing.T) {
+			if _, e := NewValidationAsyncRule("tag", nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("create", func(t *testing.T) {
+			sut, e := NewValidationAsyncRule("tag", func(context.Context, interface{}, string) (bool, error) {
+				return true, nil
+			})
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case sut.Tag() != "tag":
+				t.Errorf("(%v) when expecting (tag)", sut.Tag())
+			}
+		})
+	})
+}
+
+func Test_ValidatorCtx(t *testing.T) {
+	store := &validationTestStore{
+		emails: map[string]bool{"taken@example.com": true},
+		ids:    map[string]bool{"core": true},
+	}
+	uniqueEmail, _ := NewValidationAsyncRule("unique_email", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+		taken, e := store.has(ctx, store.emails, value)
+		return !taken, e
+	})
+	exists, _ := NewValidationAsyncRule("exists", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+		return store.has(ctx, store.ids, value)
+	})
+	uniqueEmailMsg, _ := NewValidationRule("unique_email", 400, nil, map[string]string{
+		"en": "{0} is already in use",
+		"es": "{0} ya está en uso",
+	})
+	existsMsg, _ := NewValidationRule("exists", 401, nil, map[string]string{
+		"en": "{0} must exist in {1}",
+	})
+
+	prev := ValidationLocales
+	ValidationLocales = []string{"en", "es"}
+	defer func() { ValidationLocales = prev }()
+
+	universal, _ := NewValidationLocalesUniversalTranslator()
+	translator, _ := NewValidationTranslator(universal)
+	rules := []ValidationRule{uniqueEmailMsg, existsMsg}
+	parser, _ := NewValidationRulesParser(translator, rules)
+	validate, _ := NewValidatorWithRules(translator, parser, universal, rules, nil)
+
+	t.Run("NewValidatorCtx", func(t *testing.T) {
+		scenarios := []struct {
+			name string
+			call func() (ValidatorCtx, error)
+		}{
+			{
+				name: "nil translator",
+				call: func() (ValidatorCtx, error) {
+					return NewValidatorCtx(nil, parser, universal, validate, nil)
+				},
+			},
+			{
+				name: "nil parser",
+				call: func() (ValidatorCtx, error) {
+					return NewValidatorCtx(translator, nil, universal, validate, nil)
+				},
+			},
+			{
+				name: "nil universal translator",
+				call: func() (ValidatorCtx, error) {
+					return NewValidatorCtx(translator, parser, nil, validate, nil)
+				},
+			},
+			{
+				name: "nil validator",
+				call: func() (ValidatorCtx, error) {
+					return NewValidatorCtx(translator, parser, universal, nil, nil)
+				},
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				if _, e := scenario.call(); !errors.Is(e, slate.ErrNilPointer) {
+					t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+				}
+			})
+		}
+	})
+
+	sut, e := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{nil, uniqueEmail, exists})
+	if e != nil {
+		t.Fatalf("unexpected (%v) error", e)
+	}
+
+	check := func(t *testing.T, env *Envelope, code, param int, field, path, message string) {
+		switch {
+		case env == nil || len(env.Status.Errors) != 1:
+			t.Errorf("unexpected (%v) envelope", env)
+		case env.Status.Errors[0].Error != NewEnvelopeStatusError(code, "").Error:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Error, code)
+		case env.Status.Errors[0].Param != param:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Param, param)
+		case env.Status.Errors[0].Field != field:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Field, field)
+		case env.Status.Errors[0].Path != path:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Path, path)
+		case env.Status.Errors[0].Message != message:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Message, message)
+		}
+	}
+
+	t.Run("nil context", func(t *testing.T) {
+		//nolint:staticcheck
+		if _, e := sut(nil, validationTestAccount{}); !errors.Is(e, slate.ErrNilPointer) {
+			t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+		}
+	})
+
+	t.Run("valid structure", func(t *testing.T) {
+		data := validationTestAccount{Email: "free@example.com", Team: "core"}
+
+		if env, e := sut(context.Background(), data); e != nil || env != nil {
+			t.Errorf("unexpected (%v, %v) result", env, e)
+		}
+	})
+
+	t.Run("synchronous failure skips the asynchronous rules", func(t *testing.T) {
+		calls := int32(0)
+		counted, _ := NewValidationAsyncRule("unique_email", func(context.Context, interface{}, string) (bool, error) {
+			atomic.AddInt32(&calls, 1)
+			return false, nil
+		})
+		sut, _ := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{counted, exists})
+
+		env, _ := sut(context.Background(), &validationTestAccount{Team: "core"})
+		check(t, env, 104, 1, "email", "email", "email is a required field")
+		if calls != 0 {
+			t.Errorf("(%v) unexpected asynchronous checks", calls)
+		}
+	})
+
+	t.Run("asynchronous rule failure", func(t *testing.T) {
+		data := &validationTestAccount{Email: "taken@example.com", Team: "core"}
+
+		env, _ := sut(context.Background(), data)
+		check(t, env, 400, 1, "email", "email", "email is already in use")
+	})
+
+	t.Run("asynchronous rule failure of a self-referencing structure", func(t *testing.T) {
+		data := &validationTestAccountNode{Account: validationTestAccount{Email: "taken@example.com", Team: "core"}}
+		data.Next = data
+
+		env, _ := sut(context.Background(), data)
+		check(t, env, 400, 1, "email", "account.email", "email is already in use")
+	})
+
+	t.Run("asynchronous rule failure with param", func(t *testing.T) {
+		data := validationTestAccount{Email: "free@example.com", Team: "missing"}
+
+		env, _ := sut(context.Background(), data)
+		check(t, env, 401, 2, "team", "team", "team must exist in teams")
+	})
+
+	t.Run("nested asynchronous rule failure", func(t *testing.T) {
+		data := validationTestAccounts{Accounts: []validationTestAccount{
+			{Email: "free@example.com", Team: "core"},
+			{Email: "taken@example.com", Team: "core"},
+		}}
+
+		env, _ := sut(context.Background(), data)
+		check(t, env, 400, 1, "email", "accounts[1].email", "email is already in use")
+	})
+
+	t.Run("field names and locale options", func(t *testing.T) {
+		data := &validationTestAccount{Email: "taken@example.com", Team: "core"}
+
+		env, _ := sut(context.Background(), data, ValidationWithFieldTag("xml"), ValidationWithLocale("es"))
+		check(t, env, 400, 1, "mail", "mail", "mail ya está en uso")
+	})
+
+	t.Run("messages of the rules registered in the validator translators", func(t *testing.T) {
+		universal, _ := NewValidationLocalesUniversalTranslator()
+		translator, _ := NewValidationTranslator(universal)
+		_, _ = NewValidatorWithRules(translator, parser, universal, nil, nil)
+		validate, _ := NewValidatorWithRules(translator, parser, universal, rules, nil)
+		sut, _ := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{uniqueEmail, exists})
+		data := &validationTestAccount{Email: "taken@example.com", Team: "core"}
+
+		env, _ := sut(context.Background(), data)
+		check(t, env, 400, 1, "email", "email", "email is already in use")
+	})
+
+	t.Run("partial validation skips the absent fields", func(t *testing.T) {
+		data := &validationTestAccount{Email: "taken@example.com", Team: "core"}
+
+		if env, e := sut(context.Background(), data, ValidationWithPartial([]byte(`{"team":"core"}`))); e != nil || env != nil {
+			t.Errorf("unexpected (%v, %v) result", env, e)
+		}
+		env, _ := sut(context.Background(), data, ValidationWithPartial([]byte(`{"email":"taken@example.com"}`)))
+		check(t, env, 400, 1, "email", "email", "email is already in use")
+	})
+
+	t.Run("invalid partial validation document", func(t *testing.T) {
+		data := &validationTestAccount{Email: "free@example.com", Team: "core"}
+
+		if _, e := sut(context.Background(), data, ValidationWithPartial([]byte(`{`))); !errors.Is(e, ErrInvalidValidationPatch) {
+			t.Errorf("(%v) when expecting (%v)", e, ErrInvalidValidationPatch)
+		}
+	})
+
+	t.Run("unknown asynchronous rule", func(t *testing.T) {
+		sut, _ := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{uniqueEmail})
+		data := validationTestAccount{Email: "free@example.com", Team: "core"}
+
+		if _, e := sut(context.Background(), data); !errors.Is(e, ErrUnknownValidationAsyncRule) {
+			t.Errorf("(%v) when expecting (%v)", e, ErrUnknownValidationAsyncRule)
+		}
+	})
+
+	unavailable := func(t *testing.T, env *Envelope) {
+		switch {
+		case env == nil || len(env.Status.Errors) != 1:
+			t.Errorf("unexpected (%v) envelope", env)
+		case env.GetStatusCode() != http.StatusServiceUnavailable:
+			t.Errorf("(%v) when expecting (%v)", env.GetStatusCode(), http.StatusServiceUnavailable)
+		case env.Status.Errors[0].Error != NewEnvelopeStatusError(ValidationAsyncErrorCode, "").Error:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Error, ValidationAsyncErrorCode)
+		case env.Status.Errors[0].Message != ValidationAsyncErrorMessage:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Message, ValidationAsyncErrorMessage)
+		}
+	}
+
+	t.Run("check error", func(t *testing.T) {
+		expected := fmt.Errorf("error message")
+		failing, _ := NewValidationAsyncRule("exists", func(context.Context, interface{}, string) (bool, error) {
+			return false, expected
+		})
+		sut, _ := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{uniqueEmail, failing})
+		data := validationTestAccount{Email: "free@example.com", Team: "core"}
+
+		if env, e := sut(context.Background(), data); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else {
+			unavailable(t, env)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		prev := ValidationAsyncTimeout
+		ValidationAsyncTimeout = 20
+		defer func() { ValidationAsyncTimeout = prev }()
+
+		slow, _ := NewValidationAsyncRule("exists", func(ctx context.Context, _ interface{}, _ string) (bool, error) {
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-time.After(time.Second):
+				return true, nil
+			}
+		})
+		sut, _ := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{uniqueEmail, slow})
+		data := validationTestAccount{Email: "free@example.com", Team: "core"}
+
+		if env, e := sut(context.Background(), data); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else {
+			unavailable(t, env)
+		}
+	})
+
+	t.Run("timeout of a rule that ignores the context", func(t *testing.T) {
+		prev := ValidationAsyncTimeout
+		ValidationAsyncTimeout = 20
+		defer func() { ValidationAsyncTimeout = prev }()
+
+		release := make(chan struct{})
+		defer close(release)
+		blocking, _ := NewValidationAsyncRule("exists", func(context.Context, interface{}, string) (bool, error) {
+			<-release
+			return true, nil
+		})
+		sut, _ := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{uniqueEmail, blocking})
+		data := validationTestAccount{Email: "free@example.com", Team: "core"}
+
+		done := make(chan *Envelope)
+		go func() {
+			env, _ := sut(context.Background(), data)
+			done <- env
+		}()
+		select {
+		case env := <-done:
+			unavailable(t, env)
+		case <-time.After(time.Second):
+			t.Error("didn't returned on the validation timeout")
+		}
+	})
+
+	t.Run("limit the concurrent checks", func(t *testing.T) {
+		prev := ValidationAsyncConcurrency
+		ValidationAsyncConcurrency = 2
+		defer func() { ValidationAsyncConcurrency = prev }()
+
+		running, peak := int32(0), int32(0)
+		tracked, _ := NewValidationAsyncRule("unique_email", func(context.Context, interface{}, string) (bool, error) {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&peak)
+				if current <= max || atomic.CompareAndSwapInt32(&peak, max, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return true, nil
+		})
+		sut, _ := NewValidatorCtx(translator, parser, universal, validate, []ValidationAsyncRule{tracked, exists})
+		data := validationTestAccounts{}
+		for i := 0; i < 6; i++ {
+			data.Accounts = append(data.Accounts, validationTestAccount{Email: fmt.Sprintf("%d@example.com", i), Team: "core"})
+		}
+
+		if env, e := sut(context.Background(), data); e != nil || env != nil {
+			t.Errorf("unexpected (%v, %v) result", env, e)
+		} else if peak > 2 {
+			t.Errorf("(%v) concurrent checks when expecting at most 2", peak)
+		}
+	})
+
+	t.Run("service register", func(t *testing.T) {
+		container := slate.NewServiceContainer()
+		_ = slate.NewConfigServiceRegister().Provide(container)
+		_ = slate.NewLogServiceRegister().Provide(container)
+		_ = NewValidationServiceRegister().Provide(container)
+		_ = container.Add("rule", func() ValidationRule { return uniqueEmailMsg }, ValidationRuleTag)
+		_ = container.Add("async", func() ValidationAsyncRule { return uniqueEmail }, ValidationAsyncRuleTag)
+		_ = container.Add("other", func() string { return "other" }, ValidationAsyncRuleTag)
+
+		if list, e := container.Get(ValidationAllAsyncRulesContainerID); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if rules, ok := list.([]ValidationAsyncRule); !ok || len(rules) != 1 {
+			t.Errorf("(%v) unexpected async rules list", list)
+		}
+
+		instance, e := container.Get(ValidationAsyncContainerID)
+		if e != nil {
+			t.Fatalf("unexpected (%v) error", e)
+		}
+		env, _ := instance.(ValidatorCtx)(context.Background(), validationTestSignup{Email: "taken@example.com"})
+		check(t, env, 400, 1, "email", "email", "email is already in use")
+	})
+}
@@ -0,0 +1,246 @@
+package sapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidValidationPatch defines an error that denotes an invalid
+	// JSON merge-patch document given to a partial validation.
+	ErrInvalidValidationPatch = fmt.Errorf("invalid validation patch document")
+)
+
+func errInvalidValidationPatch(
+	arg string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrInvalidValidationPatch, arg, ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// validation partial
+// ----------------------------------------------------------------------------
+
+// ValidationWithPartial will restrict the validation to the fields present
+// in the given JSON merge-patch document. The absent fields are only
+// validated when their required_* conditions refer to fields present in
+// the document, and the arrays and maps present in the document are fully
+// validated, as they replace the stored ones. The struct level validations
+// are always executed.
+func ValidationWithPartial(
+	body []byte,
+) ValidationOption {
+	return func(opts *validationOptions) {
+		opts.partial = true
+		opts.body = body
+	}
+}
+
+func (o validationOptions) filter(
+	value interface{},
+) (validator.FilterFunc, error) {
+	if !o.partial {
+		return nil, nil
+	}
+	return validationPartialFilter(value, o.body)
+}
+
+type validationPartial struct {
+	included map[string]bool
+	complete []string
+}
+
+func validationPartialFilter(
+	value interface{},
+	body []byte,
+) (validator.FilterFunc, error) {
+	// decode the patch document keys
+	var raw interface{}
+	if e := json.Unmarshal(body, &raw); e != nil {
+		return nil, errInvalidValidationPatch(e.Error())
+	}
+	document, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, errInvalidValidationPatch(string(body))
+	}
+	// collect the struct namespaces of the patched fields, using the
+	// root type name prefix as the validator namespaces
+	typeof := reflect.TypeOf(value)
+	for typeof.Kind() == reflect.Pointer {
+		typeof = typeof.Elem()
+	}
+	ns := ""
+	if typeof.Name() != "" {
+		ns = typeof.Name() + "."
+	}
+	partial := &validationPartial{included: map[string]bool{}}
+	partial.walk(typeof, document, ns)
+	// return the validator filter function, that skips the fields
+	// not included in the partial validation
+	return func(ns []byte) bool {
+		return !partial.includes(string(ns))
+	}, nil
+}
+
+func (p *validationPartial) includes(
+	ns string,
+) bool {
+	if p.included[ns] {
+		return true
+	}
+	for _, c := range p.complete {
+		if strings.HasPrefix(ns, c+".") || strings.HasPrefix(ns, c+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *validationPartial) walk(
+	typeof reflect.Type,
+	document map[string]interface{},
+	ns string,
+) {
+	if typeof.Kind() != reflect.Struct {
+		return
+	}
+	// mark the structure fields present in the document
+	var absent []reflect.StructField
+	for i := 0; i < typeof.NumField(); i++ {
+		field := typeof.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		fieldNs := ns + field.Name
+		key, named := validationPartialKey(field)
+		// embedded structures fields are decoded from the same document
+		if field.Anonymous && !named {
+			p.included[fieldNs] = true
+			p.walk(validationPartialElem(field.Type), document, fieldNs+".")
+			continue
+		}
+		value, ok := validationPartialLookup(document, key)
+		if !ok {
+			absent = append(absent, field)
+			continue
+		}
+		p.included[fieldNs] = true
+		elem := validationPartialElem(field.Type)
+		switch elem.Kind() {
+		case reflect.Struct:
+			if nested, ok := value.(map[string]interface{}); ok {
+				p.walk(elem, nested, fieldNs+".")
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			p.complete = append(p.complete, fieldNs)
+		}
+	}
+	// include the absent fields with a required condition that can be
+	// evaluated with the present fields
+	for _, field := range absent {
+		if p.conditioned(field, ns) {
+			p.included[ns+field.Name] = true
+		}
+	}
+}
+
+func (p *validationPartial) conditioned(
+	field reflect.StructField,
+	ns string,
+) bool {
+	for _, group := range strings.Split(field.Tag.Get("validate"), ",") {
+		for _, tag := range strings.Split(group, "|") {
+			name, param, _ := strings.Cut(tag, "=")
+			refs := strings.Fields(param)
+			switch name {
+			case "dive", "keys":
+				// the remaining tags are applied to the field elements
+				return false
+			case "required_with", "required_with_all":
+				// only the present fields can trigger the requirement
+				for _, ref := range refs {
+					if p.included[ns+ref] {
+						return true
+					}
+				}
+			case "required_if", "required_unless":
+				// the parameter is a list of field and value pairs
+				var fields []string
+				for i := 0; i < len(refs); i += 2 {
+					fields = append(fields, refs[i])
+				}
+				if p.all(ns, fields) {
+					return true
+				}
+			case "required_without", "required_without_all":
+				if p.all(ns, refs) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (p *validationPartial) all(
+	ns string,
+	refs []string,
+) bool {
+	if len(refs) == 0 {
+		return false
+	}
+	for _, ref := range refs {
+		if !p.included[ns+ref] {
+			return false
+		}
+	}
+	return true
+}
+
+func validationPartialKey(
+	field reflect.StructField,
+) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name, false
+	}
+	return name, true
+}
+
+func validationPartialLookup(
+	document map[string]interface{},
+	key string,
+) (interface{}, bool) {
+	if key == "-" {
+		return nil, false
+	}
+	if value, ok := document[key]; ok {
+		return value, true
+	}
+	// fallback to the case-insensitive match of the json decoder
+	for k, value := range document {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func validationPartialElem(
+	typeof reflect.Type,
+) reflect.Type {
+	for typeof.Kind() == reflect.Pointer {
+		typeof = typeof.Elem()
+	}
+	return typeof
+}
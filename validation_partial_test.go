@@ -0,0 +1,167 @@
+package sapi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type validationTestPatchAddress struct {
+	Street string `json:"street" validate:"required" vparam:"6"`
+	City   string `json:"city" validate:"required" vparam:"7"`
+}
+
+type validationTestPatchTag struct {
+	Name string `json:"name" validate:"required" vparam:"8"`
+}
+
+type validationTestPatchAudit struct {
+	Reason string `json:"reason" validate:"required" vparam:"9"`
+	Author string `json:"author" validate:"required" vparam:"10"`
+}
+
+type validationTestPatchProfile struct {
+	Name            string                      `json:"name" validate:"required,min=3" vparam:"1"`
+	Email           string                      `json:"email" validate:"required" vparam:"2"`
+	Password        string                      `json:"password" validate:"omitempty,min=8" vparam:"3"`
+	PasswordConfirm string                      `json:"password_confirm" validate:"required_with=Password" vparam:"4"`
+	Phone           string                      `json:"phone" validate:"required_without=Email" vparam:"5"`
+	Address         *validationTestPatchAddress `json:"address"`
+	Tags            []validationTestPatchTag    `json:"tags" validate:"dive"`
+	validationTestPatchAudit
+}
+
+func Test_Validator_partial(t *testing.T) {
+	universal := NewValidationUniversalTranslator()
+	translator, _ := NewValidationTranslator(universal)
+	parser, _ := NewValidationParser(translator)
+	sut, e := NewValidatorWithRules(translator, parser, universal, nil, nil)
+	if e != nil {
+		t.Fatalf("unexpected (%v) error", e)
+	}
+
+	t.Run("invalid patch document", func(t *testing.T) {
+		scenarios := []struct {
+			name string
+			body string
+		}{
+			{name: "malformed", body: `{"name":`},
+			{name: "not an object", body: `["name"]`},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				if _, e := sut(&validationTestPatchProfile{}, ValidationWithPartial([]byte(scenario.body))); !errors.Is(e, ErrInvalidValidationPatch) {
+					t.Errorf("(%v) when expecting (%v)", e, ErrInvalidValidationPatch)
+				}
+			})
+		}
+	})
+
+	t.Run("validate the patched fields", func(t *testing.T) {
+		type expected struct {
+			param int
+			path  string
+		}
+		scenarios := []struct {
+			name     string
+			body     string
+			expected []expected
+		}{
+			{
+				name: "valid patch",
+				body: `{"name":"john"}`,
+			},
+			{
+				name:     "invalid patched field",
+				body:     `{"name":"jo"}`,
+				expected: []expected{{param: 1, path: "name"}},
+			},
+			{
+				name:     "case insensitive keys",
+				body:     `{"NAME":"jo"}`,
+				expected: []expected{{param: 1, path: "name"}},
+			},
+			{
+				name:     "emptied required field",
+				body:     `{"email":"","phone":"123"}`,
+				expected: []expected{{param: 2, path: "email"}},
+			},
+			{
+				name:     "triggered required_with",
+				body:     `{"password":"password"}`,
+				expected: []expected{{param: 4, path: "password_confirm"}},
+			},
+			{
+				name: "satisfied required_with",
+				body: `{"password":"password","password_confirm":"password"}`,
+			},
+			{
+				name:     "evaluated required_without",
+				body:     `{"email":""}`,
+				expected: []expected{{param: 2, path: "email"}, {param: 5, path: "phone"}},
+			},
+			{
+				name:     "merged nested structure",
+				body:     `{"address":{"city":""}}`,
+				expected: []expected{{param: 7, path: "address.city"}},
+			},
+			{
+				name:     "replaced list",
+				body:     `{"tags":[{"name":"tag"},{}]}`,
+				expected: []expected{{param: 8, path: "tags[1].name"}},
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				data := &validationTestPatchProfile{}
+				_ = json.Unmarshal([]byte(scenario.body), data)
+
+				env, e := sut(data, ValidationWithPartial([]byte(scenario.body)))
+				switch {
+				case e != nil:
+					t.Errorf("unexpected (%v) error", e)
+				case len(scenario.expected) == 0 && env != nil:
+					t.Errorf("unexpected (%v) envelope", env)
+				case len(scenario.expected) == 0:
+				case env == nil || len(env.Status.Errors) != len(scenario.expected):
+					t.Errorf("unexpected (%v) envelope", env)
+				default:
+					for i, expected := range scenario.expected {
+						if env.Status.Errors[i].Param != expected.param || env.Status.Errors[i].Path != expected.path {
+							t.Errorf("(%v) when expecting (%v)", env.Status.Errors[i], expected)
+						}
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("embedded structure", func(t *testing.T) {
+		body := []byte(`{"reason":""}`)
+
+		if env, _ := sut(&validationTestPatchProfile{}, ValidationWithPartial(body)); env == nil || len(env.Status.Errors) != 1 || env.Status.Errors[0].Param != 9 {
+			t.Errorf("unexpected (%v) envelope", env)
+		}
+	})
+
+	t.Run("validate all the fields without partial mode", func(t *testing.T) {
+		data := &validationTestPatchProfile{Name: "john"}
+
+		if env, _ := sut(data); env == nil || len(env.Status.Errors) != 4 || env.Status.Errors[0].Param != 2 {
+			t.Errorf("unexpected (%v) envelope", env)
+		}
+	})
+
+	t.Run("anonymous structure", func(t *testing.T) {
+		data := struct {
+			A string `json:"a" validate:"required"`
+			B string `json:"b" validate:"required"`
+		}{A: "a"}
+
+		if env, e := sut(data, ValidationWithPartial([]byte(`{"a":"a"}`))); e != nil || env != nil {
+			t.Errorf("unexpected (%v, %v) result", env, e)
+		}
+	})
+}
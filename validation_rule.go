@@ -0,0 +1,165 @@
+package sapi
+
+import (
+	"fmt"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/happyhippyhippo/slate"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// ValidationRuleTag defines the tag to be assigned to all the custom
+	// validation rules registered in the application container.
+	ValidationRuleTag = ValidationContainerID + ".rule"
+
+	// ValidationAllRulesContainerID defines the id to be used as the
+	// container registration id of the list of all custom validation rules.
+	ValidationAllRulesContainerID = ValidationRuleTag + ".all"
+)
+
+// ----------------------------------------------------------------------------
+// errors
+// ----------------------------------------------------------------------------
+
+var (
+	// ErrInvalidValidationRule defines an error that denotes an invalid
+	// custom validation rule definition.
+	ErrInvalidValidationRule = fmt.Errorf("invalid validation rule")
+)
+
+func errInvalidValidationRule(
+	tag string,
+	ctx ...map[string]interface{},
+) error {
+	return slate.NewErrorFrom(ErrInvalidValidationRule, tag, ctx...)
+}
+
+// ----------------------------------------------------------------------------
+// validation rule
+// ----------------------------------------------------------------------------
+
+// ValidationRule defines the interface of a custom validation rule that
+// will be registered in the validator with its own validation tag,
+// validation function, per locale message templates and envelope
+// error code. A rule without validation function only defines the
+// message and code of a tag reported by struct level validations.
+//
+// The message templates can refer to the field name as {0} and to the
+// tag parameter as {1}.
+type ValidationRule interface {
+	Tag() string
+	Func() validator.Func
+	Translations() map[string]string
+	Code() int
+}
+
+type validationRule struct {
+	tag          string
+	fn           validator.Func
+	translations map[string]string
+	code         int
+}
+
+var _ ValidationRule = &validationRule{}
+
+// NewValidationRule will instantiate a simple custom validation rule.
+func NewValidationRule(
+	tag string,
+	code int,
+	fn validator.Func,
+	translations map[string]string,
+) (ValidationRule, error) {
+	// check the rule tag argument
+	if tag == "" {
+		return nil, errInvalidValidationRule(tag)
+	}
+	return &validationRule{
+		tag:          tag,
+		fn:           fn,
+		translations: translations,
+		code:         code,
+	}, nil
+}
+
+// Tag retrieves the rule validation tag.
+func (r validationRule) Tag() string {
+	return r.tag
+}
+
+// Func retrieves the rule validation function.
+func (r validationRule) Func() validator.Func {
+	return r.fn
+}
+
+// Translations retrieves the rule message templates indexed by locale.
+func (r validationRule) Translations() map[string]string {
+	return r.translations
+}
+
+// Code retrieves the rule envelope error code.
+func (r validationRule) Code() int {
+	return r.code
+}
+
+func validationRegisterRules(
+	validate *validator.Validate,
+	translators []ut.Translator,
+	rules []ValidationRule,
+) error {
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		// register the rule validation function
+		if fn := rule.Func(); fn != nil {
+			if e := validate.RegisterValidation(rule.Tag(), fn); e != nil {
+				return e
+			}
+		}
+		// register the rule message on every translator, using the default
+		// locale template on the locales without a specific one
+		templates := rule.Translations()
+		for _, translator := range translators {
+			template, ok := templates[translator.Locale()]
+			if !ok {
+				if template, ok = templates[ValidationLocale]; !ok {
+					continue
+				}
+			}
+			if e := validate.RegisterTranslation(
+				rule.Tag(),
+				translator,
+				validationRuleRegistration(rule.Tag(), template),
+				validationRuleTranslation,
+			); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func validationRuleRegistration(
+	tag string,
+	template string,
+) validator.RegisterTranslationsFunc {
+	return func(translator ut.Translator) error {
+		return translator.Add(tag, template, true)
+	}
+}
+
+func validationRuleTranslation(
+	translator ut.Translator,
+	fe validator.FieldError,
+) string {
+	msg, e := translator.T(fe.Tag(), fe.Field(), fe.Param())
+	if e != nil {
+		return fe.Error()
+	}
+	return msg
+}
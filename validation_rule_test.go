@@ -0,0 +1,144 @@
+package sapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/happyhippyhippo/slate"
+)
+
+func Test_ValidationRule(t *testing.T) {
+	even := func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}
+
+	t.Run("NewValidationRule", func(t *testing.T) {
+		t.Run("empty tag", func(t *testing.T) {
+			if _, e := NewValidationRule("", 200, even, nil); !errors.Is(e, ErrInvalidValidationRule) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrInvalidValidationRule)
+			}
+		})
+
+		t.Run("message only rule", func(t *testing.T) {
+			if sut, e := NewValidationRule("even", 200, nil, nil); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if sut.Func() != nil {
+				t.Error("unexpected validation function")
+			}
+		})
+
+		t.Run("create", func(t *testing.T) {
+			translations := map[string]string{"en": "{0} must be even"}
+			sut, e := NewValidationRule("even", 200, even, translations)
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case sut.Tag() != "even":
+				t.Errorf("(%v) when expecting (even)", sut.Tag())
+			case sut.Code() != 200:
+				t.Errorf("(%v) when expecting (200)", sut.Code())
+			case sut.Func() == nil:
+				t.Error("didn't stored the validation function")
+			case sut.Translations()["en"] != translations["en"]:
+				t.Errorf("(%v) when expecting (%v)", sut.Translations(), translations)
+			}
+		})
+	})
+
+	t.Run("register in the validator", func(t *testing.T) {
+		data := struct {
+			Field int `validate:"even" vparam:"3"`
+		}{Field: 1}
+
+		prev := ValidationLocales
+		ValidationLocales = []string{"en", "es", "fr"}
+		defer func() { ValidationLocales = prev }()
+
+		rule, _ := NewValidationRule("even", 200, even, map[string]string{
+			"en": "{0} must be even",
+			"es": "{0} debe ser par",
+		})
+		universal, _ := NewValidationLocalesUniversalTranslator()
+		translator, _ := NewValidationTranslator(universal)
+		parser, _ := NewValidationRulesParser(translator, []ValidationRule{nil, rule})
+
+		t.Run("invalid rule tag", func(t *testing.T) {
+			invalid := &validationRule{tag: "", fn: even}
+
+			if _, e := NewValidatorWithRules(translator, parser, universal, []ValidationRule{invalid}, nil); e == nil {
+				t.Error("didn't returned the expected error")
+			}
+		})
+
+		sut, e := NewValidatorWithRules(translator, parser, universal, []ValidationRule{nil, rule}, nil)
+		if e != nil {
+			t.Fatalf("unexpected (%v) error", e)
+		}
+
+		t.Run("valid value", func(t *testing.T) {
+			valid := data
+			valid.Field = 2
+
+			if env, e := sut(valid); e != nil || env != nil {
+				t.Errorf("unexpected (%v, %v) result", env, e)
+			}
+		})
+
+		scenarios := []struct {
+			locale   string
+			expected string
+		}{
+			{locale: "en", expected: "Field must be even"},
+			{locale: "es", expected: "Field debe ser par"},
+			{locale: "fr", expected: "Field must be even"},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run("invalid value in "+scenario.locale, func(t *testing.T) {
+				env, e := sut(data, ValidationWithLocale(scenario.locale))
+				switch {
+				case e != nil:
+					t.Errorf("unexpected (%v) error", e)
+				case env == nil || len(env.Status.Errors) != 1:
+					t.Errorf("unexpected (%v) envelope", env)
+				case env.Status.Errors[0].Error != "200":
+					t.Errorf("(%v) when expecting (200)", env.Status.Errors[0].Error)
+				case env.Status.Errors[0].Param != 3:
+					t.Errorf("(%v) when expecting (3)", env.Status.Errors[0].Param)
+				case env.Status.Errors[0].Message != scenario.expected:
+					t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Message, scenario.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("service register", func(t *testing.T) {
+		rule, _ := NewValidationRule("even", 200, even, map[string]string{"en": "{0} must be even"})
+		container := slate.NewServiceContainer()
+		_ = slate.NewConfigServiceRegister().Provide(container)
+		_ = slate.NewLogServiceRegister().Provide(container)
+		_ = NewValidationServiceRegister().Provide(container)
+		_ = container.Add("rule", func() ValidationRule { return rule }, ValidationRuleTag)
+		_ = container.Add("other", func() string { return "other" }, ValidationRuleTag)
+
+		if rules, e := container.Get(ValidationAllRulesContainerID); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if list, ok := rules.([]ValidationRule); !ok || len(list) != 1 || list[0] != rule {
+			t.Errorf("(%v) unexpected rules list", rules)
+		}
+
+		instance, e := container.Get(ValidationContainerID)
+		if e != nil {
+			t.Fatalf("unexpected (%v) error", e)
+		}
+		sut := instance.(Validator)
+		data := struct {
+			Field int `validate:"even"`
+		}{Field: 1}
+
+		if env, _ := sut(data); env == nil || env.Status.Errors[0].Error != "200" {
+			t.Errorf("unexpected (%v) envelope", env)
+		}
+	})
+}
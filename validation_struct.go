@@ -0,0 +1,470 @@
+package sapi
+
+import (
+	"fmt"
+	"reflect"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// ----------------------------------------------------------------------------
+// defs
+// ----------------------------------------------------------------------------
+
+const (
+	// ValidationStructRuleTag defines the tag to be assigned to all the
+	// struct level validation rules registered in the application container.
+	ValidationStructRuleTag = ValidationContainerID + ".struct_rule"
+
+	// ValidationAllStructRulesContainerID defines the id to be used as the
+	// container registration id of the list of all struct level rules.
+	ValidationAllStructRulesContainerID = ValidationStructRuleTag + ".all"
+)
+
+// ----------------------------------------------------------------------------
+// validation struct level
+// ----------------------------------------------------------------------------
+
+// ValidationStructValidator defines the interface that a validated
+// structure can implement to perform struct level and cross-field
+// validations, reporting the failures with ValidationReportError.
+// The method is called for the validated structure, and any nested
+// structure, that implements it, unless a struct level rule is registered
+// for the structure type.
+type ValidationStructValidator interface {
+	ValidateStruct(sl validator.StructLevel)
+}
+
+// ValidationStructRule defines the interface of a struct level validation
+// rule registered for a list of structure types.
+type ValidationStructRule interface {
+	Types() []interface{}
+	Func() validator.StructLevelFunc
+}
+
+type validationStructRule struct {
+	types []interface{}
+	fn    validator.StructLevelFunc
+}
+
+var _ ValidationStructRule = &validationStructRule{}
+
+// NewValidationStructRule will instantiate a struct level validation rule
+// for the given structure types.
+func NewValidationStructRule(
+	fn validator.StructLevelFunc,
+	types ...interface{},
+) (ValidationStructRule, error) {
+	// check the validation function argument reference
+	if fn == nil {
+		return nil, errNilPointer("fn")
+	}
+	return &validationStructRule{
+		types: types,
+		fn:    fn,
+	}, nil
+}
+
+// Types retrieves the structure types validated by the rule.
+func (r validationStructRule) Types() []interface{} {
+	return r.types
+}
+
+// Func retrieves the rule struct level validation function.
+func (r validationStructRule) Func() validator.StructLevelFunc {
+	return r.fn
+}
+
+// ValidationReportError will report a struct level validation failure of
+// the given struct field name. The reported tag error code and message are
+// the ones defined by the validation rule registered with that tag,
+// and the field is named as in the validation tag errors.
+func ValidationReportError(
+	sl validator.StructLevel,
+	field string,
+	tag string,
+	param string,
+) {
+	current := sl.Current()
+	name := field
+	var value interface{}
+	if structField, ok := current.Type().FieldByName(field); ok {
+		value = current.FieldByIndex(structField.Index).Interface()
+		if named, ok := sl.(validationStructNamer); ok {
+			if tagged := named.fieldName(structField); tagged != "" {
+				name = tagged
+			}
+		}
+	}
+	sl.ReportError(value, name, field, tag, param)
+}
+
+// validationStructNamer defines the struct levels that can name the
+// fields alike the validator field naming function.
+type validationStructNamer interface {
+	fieldName(field reflect.StructField) string
+}
+
+// validationStructLevel decorates the struct level given to the rules
+// with the validator field naming function, so the struct level reports
+// can name the fields alike.
+type validationStructLevel struct {
+	validator.StructLevel
+	tagName func(reflect.StructField) string
+}
+
+func (s validationStructLevel) fieldName(
+	field reflect.StructField,
+) string {
+	return s.tagName(field)
+}
+
+// validationStructReport defines the struct level given to the
+// ValidationStructValidator implementations that have no registered rule,
+// collecting the reported failures.
+type validationStructReport struct {
+	validate *validator.Validate
+	tagName  func(reflect.StructField) string
+	top      reflect.Value
+	parent   reflect.Value
+	current  reflect.Value
+	ns       string
+	structNs string
+	errs     validator.ValidationErrors
+}
+
+var _ validator.StructLevel = &validationStructReport{}
+
+func (s *validationStructReport) Validator() *validator.Validate { return s.validate }
+func (s *validationStructReport) Top() reflect.Value             { return s.top }
+func (s *validationStructReport) Parent() reflect.Value          { return s.parent }
+func (s *validationStructReport) Current() reflect.Value         { return s.current }
+
+func (s *validationStructReport) fieldName(
+	field reflect.StructField,
+) string {
+	return s.tagName(field)
+}
+
+func (s *validationStructReport) ExtractType(
+	field reflect.Value,
+) (reflect.Value, reflect.Kind, bool) {
+	nullable := false
+	for field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return field, field.Kind(), true
+		}
+		nullable = true
+		field = field.Elem()
+	}
+	return field, field.Kind(), nullable
+}
+
+func (s *validationStructReport) ReportError(
+	field interface{},
+	fieldName string,
+	structFieldName string,
+	tag string,
+	param string,
+) {
+	if structFieldName == "" {
+		structFieldName = fieldName
+	}
+	fe := &validationFieldError{
+		tag:             tag,
+		param:           param,
+		field:           fieldName,
+		structField:     structFieldName,
+		namespace:       validationWalkerJoin(s.ns, fieldName),
+		structNamespace: validationWalkerJoin(s.structNs, structFieldName),
+	}
+	if value, _, _ := s.ExtractType(reflect.ValueOf(field)); value.IsValid() && value.CanInterface() {
+		fe.value = value.Interface()
+		fe.typeof = value.Type()
+	}
+	s.errs = append(s.errs, fe)
+}
+
+func (s *validationStructReport) ReportValidationErrors(
+	relativeNamespace string,
+	relativeStructNamespace string,
+	errs validator.ValidationErrors,
+) {
+	for _, e := range errs {
+		s.errs = append(s.errs, &validationFieldError{
+			tag:             e.Tag(),
+			param:           e.Param(),
+			field:           e.Field(),
+			structField:     e.StructField(),
+			namespace:       validationWalkerJoin(s.ns, relativeNamespace+e.Namespace()),
+			structNamespace: validationWalkerJoin(s.structNs, relativeStructNamespace+e.StructNamespace()),
+			value:           e.Value(),
+			typeof:          e.Type(),
+		})
+	}
+}
+
+func validationStructValidatorOf(
+	current reflect.Value,
+) (ValidationStructValidator, bool) {
+	// use an addressable copy of the structure to also call the
+	// pointer receiver implementations
+	if !current.CanAddr() {
+		c := reflect.New(current.Type()).Elem()
+		c.Set(current)
+		current = c
+	}
+	v, ok := current.Addr().Interface().(ValidationStructValidator)
+	return v, ok
+}
+
+// validationStructValidate calls the ValidateStruct method of all the
+// structures reachable from the given value that implement the
+// ValidationStructValidator interface, and whose type has no registered
+// struct level rule, returning the reported failures.
+func validationStructValidate(
+	validate *validator.Validate,
+	value interface{},
+	tagName func(reflect.StructField) string,
+	filter validator.FilterFunc,
+	registered map[reflect.Type]bool,
+) validator.ValidationErrors {
+	var errs validator.ValidationErrors
+	top := reflect.Indirect(reflect.ValueOf(value))
+	walker := newValidationWalker(tagName, filter)
+	_ = walker.walk(value, func(parent, current reflect.Value, ns, structNs string) error {
+		if registered[current.Type()] {
+			return nil
+		}
+		if v, ok := validationStructValidatorOf(current); ok {
+			report := &validationStructReport{
+				validate: validate,
+				tagName:  tagName,
+				top:      top,
+				parent:   parent,
+				current:  current,
+				ns:       ns,
+				structNs: structNs,
+			}
+			v.ValidateStruct(report)
+			errs = append(errs, report.errs...)
+		}
+		return nil
+	})
+	return errs
+}
+
+func validationRegisterStructRules(
+	validate *validator.Validate,
+	tagName func(reflect.StructField) string,
+	rules []ValidationStructRule,
+) map[reflect.Type]bool {
+	// register the struct level rules, where the rules registered later
+	// for the same structure type replace the previous ones
+	registered := map[reflect.Type]bool{}
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		fn := rule.Func()
+		validate.RegisterStructValidation(func(sl validator.StructLevel) {
+			fn(&validationStructLevel{StructLevel: sl, tagName: tagName})
+		}, rule.Types()...)
+		for _, t := range rule.Types() {
+			typeof := reflect.TypeOf(t)
+			for typeof != nil && typeof.Kind() == reflect.Pointer {
+				typeof = typeof.Elem()
+			}
+			registered[typeof] = true
+		}
+	}
+	return registered
+}
+
+// ----------------------------------------------------------------------------
+// validation walker
+// ----------------------------------------------------------------------------
+
+// validationWalker walks the structures reachable from a validated value,
+// naming their fields alike the validator, and following each pointer
+// only once so the self-referencing values can be walked.
+type validationWalker struct {
+	tagName func(reflect.StructField) string
+	filter  validator.FilterFunc
+	visited map[validationWalkerPointer]bool
+}
+
+type validationWalkerPointer struct {
+	address uintptr
+	typeof  reflect.Type
+}
+
+func newValidationWalker(
+	tagName func(reflect.StructField) string,
+	filter validator.FilterFunc,
+) *validationWalker {
+	return &validationWalker{
+		tagName: tagName,
+		filter:  filter,
+		visited: map[validationWalkerPointer]bool{},
+	}
+}
+
+// walk calls the visit function for all the structures reachable from the
+// given value, where the namespaces start with the root type name, as the
+// validator ones.
+func (w *validationWalker) walk(
+	value interface{},
+	visit func(parent, current reflect.Value, ns, structNs string) error,
+) error {
+	root := reflect.ValueOf(value)
+	typeof := reflect.TypeOf(value)
+	for typeof.Kind() == reflect.Pointer {
+		typeof = typeof.Elem()
+	}
+	return w.value(root, reflect.Indirect(root), typeof.Name(), typeof.Name(), visit)
+}
+
+func (w *validationWalker) value(
+	v reflect.Value,
+	parent reflect.Value,
+	ns string,
+	structNs string,
+	visit func(parent, current reflect.Value, ns, structNs string) error,
+) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Pointer {
+			pointer := validationWalkerPointer{address: v.Pointer(), typeof: v.Type()}
+			if w.visited[pointer] {
+				return nil
+			}
+			w.visited[pointer] = true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			index := fmt.Sprintf("[%d]", i)
+			if e := w.value(v.Index(i), parent, ns+index, structNs+index, visit); e != nil {
+				return e
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			index := fmt.Sprintf("[%v]", iter.Key().Interface())
+			if e := w.value(iter.Value(), parent, ns+index, structNs+index, visit); e != nil {
+				return e
+			}
+		}
+	case reflect.Struct:
+		if e := visit(parent, v, ns, structNs); e != nil {
+			return e
+		}
+		return w.fields(v, ns, structNs, func(i int, _ reflect.StructField, fieldNs, fieldStructNs string) error {
+			return w.value(v.Field(i), v, fieldNs, fieldStructNs, visit)
+		})
+	}
+	return nil
+}
+
+// fields calls the given function for all the exported fields of the
+// given structure that aren't excluded by the walker filter.
+func (w *validationWalker) fields(
+	v reflect.Value,
+	ns string,
+	structNs string,
+	fn func(i int, field reflect.StructField, ns, structNs string) error,
+) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldNs := validationWalkerJoin(ns, w.name(field))
+		fieldStructNs := validationWalkerJoin(structNs, field.Name)
+		// skip the fields excluded from a partial validation
+		if w.filter != nil && w.filter([]byte(fieldStructNs)) {
+			continue
+		}
+		if e := fn(i, field, fieldNs, fieldStructNs); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// name retrieves the field name used by the validator, falling back to
+// the struct field name.
+func (w *validationWalker) name(
+	field reflect.StructField,
+) string {
+	if name := w.tagName(field); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func validationWalkerJoin(
+	namespace string,
+	name string,
+) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// ----------------------------------------------------------------------------
+// validation field error
+// ----------------------------------------------------------------------------
+
+// validationFieldError defines a field validation failure reported
+// outside the validator, translated by the registered rule messages.
+type validationFieldError struct {
+	tag             string
+	param           string
+	field           string
+	structField     string
+	namespace       string
+	structNamespace string
+	value           interface{}
+	typeof          reflect.Type
+}
+
+var _ validator.FieldError = &validationFieldError{}
+
+func (e validationFieldError) Tag() string             { return e.tag }
+func (e validationFieldError) ActualTag() string       { return e.tag }
+func (e validationFieldError) Namespace() string       { return e.namespace }
+func (e validationFieldError) StructNamespace() string { return e.structNamespace }
+func (e validationFieldError) Field() string           { return e.field }
+func (e validationFieldError) StructField() string     { return e.structField }
+func (e validationFieldError) Value() interface{}      { return e.value }
+func (e validationFieldError) Param() string           { return e.param }
+func (e validationFieldError) Type() reflect.Type      { return e.typeof }
+
+func (e validationFieldError) Kind() reflect.Kind {
+	if e.typeof == nil {
+		return reflect.Invalid
+	}
+	return e.typeof.Kind()
+}
+
+func (e validationFieldError) Translate(
+	translator ut.Translator,
+) string {
+	if translator == nil {
+		return e.Error()
+	}
+	return validationRuleTranslation(translator, e)
+}
+
+func (e validationFieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", e.namespace, e.field, e.tag)
+}
@@ -0,0 +1,215 @@
+package sapi
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/happyhippyhippo/slate"
+)
+
+type validationTestPeriod struct {
+	Start     time.Time `json:"start" xml:"start"`
+	End       time.Time `json:"end_date" xml:"endDate" vparam:"5"`
+	OpenEnded bool      `json:"open_ended" xml:"openEnded"`
+}
+
+func (p *validationTestPeriod) ValidateStruct(sl validator.StructLevel) {
+	if !p.OpenEnded && !p.End.After(p.Start) {
+		ValidationReportError(sl, "End", "after_start", "start")
+	}
+}
+
+type validationTestPeriodNode struct {
+	Period validationTestPeriod      `json:"period"`
+	Next   *validationTestPeriodNode `json:"next" validate:"-"`
+}
+
+type validationTestSchedule struct {
+	Name    string                 `json:"name" validate:"required"`
+	Periods []validationTestPeriod `json:"periods" validate:"dive"`
+}
+
+type validationTestRange struct {
+	Min int `json:"min"`
+	Max int `json:"max" vparam:"6"`
+}
+
+func Test_ValidationStructRule(t *testing.T) {
+	t.Run("NewValidationStructRule", func(t *testing.T) {
+		t.Run("nil validation function", func(t *testing.T) {
+			if _, e := NewValidationStructRule(nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("create", func(t *testing.T) {
+			sut, e := NewValidationStructRule(func(validator.StructLevel) {}, validationTestRange{})
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case sut.Func() == nil:
+				t.Error("didn't stored the validation function")
+			case len(sut.Types()) != 1:
+				t.Errorf("(%v) unexpected types", sut.Types())
+			}
+		})
+	})
+}
+
+func Test_Validator_structLevel(t *testing.T) {
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	afterStart, _ := NewValidationRule("after_start", 300, nil, map[string]string{
+		"en": "{0} must be after {1}",
+	})
+	maxRule, _ := NewValidationRule("gte_min", 301, nil, map[string]string{
+		"en": "{0} must not be lower than {1}",
+	})
+	rangeRule, _ := NewValidationStructRule(func(sl validator.StructLevel) {
+		if r := sl.Current().Interface().(validationTestRange); r.Max < r.Min {
+			ValidationReportError(sl, "Max", "gte_min", "min")
+		}
+	}, &validationTestRange{})
+	overrideRule, _ := NewValidationStructRule(func(sl validator.StructLevel) {
+		ValidationReportError(sl, "Start", "after_start", "override")
+	}, validationTestPeriod{})
+
+	universal, _ := NewValidationLocalesUniversalTranslator()
+	translator, _ := NewValidationTranslator(universal)
+	rules := []ValidationRule{afterStart, maxRule}
+	parser, _ := NewValidationRulesParser(translator, rules)
+	sut, e := NewValidatorWithRules(translator, parser, universal, rules, []ValidationStructRule{nil, rangeRule})
+	if e != nil {
+		t.Fatalf("unexpected (%v) error", e)
+	}
+
+	check := func(t *testing.T, env *Envelope, code, param int, field, path, message string) {
+		switch {
+		case env == nil || len(env.Status.Errors) != 1:
+			t.Errorf("unexpected (%v) envelope", env)
+		case env.Status.Errors[0].Error != NewEnvelopeStatusError(code, "").Error:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Error, code)
+		case env.Status.Errors[0].Param != param:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Param, param)
+		case env.Status.Errors[0].Field != field:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Field, field)
+		case env.Status.Errors[0].Path != path:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Path, path)
+		case env.Status.Errors[0].Message != message:
+			t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Message, message)
+		}
+	}
+
+	t.Run("valid structure", func(t *testing.T) {
+		data := &validationTestPeriod{Start: start, End: start.Add(time.Hour)}
+
+		if env, e := sut(data); e != nil || env != nil {
+			t.Errorf("unexpected (%v, %v) result", env, e)
+		}
+	})
+
+	t.Run("valid open ended structure", func(t *testing.T) {
+		data := validationTestPeriod{Start: start, OpenEnded: true}
+
+		if env, e := sut(data); e != nil || env != nil {
+			t.Errorf("unexpected (%v, %v) result", env, e)
+		}
+	})
+
+	t.Run("structure implemented validation", func(t *testing.T) {
+		data := &validationTestPeriod{Start: start, End: start}
+
+		env, _ := sut(data)
+		check(t, env, 300, 5, "end_date", "end_date", "end_date must be after start")
+	})
+
+	t.Run("structure implemented validation on a value", func(t *testing.T) {
+		data := validationTestPeriod{Start: start, End: start}
+
+		env, _ := sut(data)
+		check(t, env, 300, 5, "end_date", "end_date", "end_date must be after start")
+	})
+
+	t.Run("nested structure implemented validation", func(t *testing.T) {
+		data := validationTestSchedule{
+			Name: "name",
+			Periods: []validationTestPeriod{
+				{Start: start, End: start.Add(time.Hour)},
+				{Start: start, End: start},
+			},
+		}
+
+		env, _ := sut(data)
+		check(t, env, 300, 5, "end_date", "periods[1].end_date", "end_date must be after start")
+	})
+
+	t.Run("xml field names", func(t *testing.T) {
+		data := &validationTestPeriod{Start: start, End: start}
+
+		env, _ := sut(data, ValidationWithFieldTag("xml"))
+		check(t, env, 300, 5, "endDate", "endDate", "endDate must be after start")
+	})
+
+	t.Run("registered struct rule", func(t *testing.T) {
+		data := validationTestRange{Min: 2, Max: 1}
+
+		env, _ := sut(data)
+		check(t, env, 301, 6, "max", "max", "max must not be lower than min")
+	})
+
+	t.Run("registered struct rule takes precedence over the implemented validation", func(t *testing.T) {
+		sut, _ := NewValidatorWithRules(translator, parser, universal, rules, []ValidationStructRule{overrideRule})
+		data := &validationTestPeriod{Start: start, End: start}
+
+		env, _ := sut(data)
+		check(t, env, 300, 0, "start", "start", "start must be after override")
+	})
+
+	t.Run("self-referencing structure implemented validation", func(t *testing.T) {
+		data := &validationTestPeriodNode{Period: validationTestPeriod{Start: start, End: start}}
+		data.Next = data
+
+		env, _ := sut(data)
+		check(t, env, 300, 5, "end_date", "period.end_date", "end_date must be after start")
+	})
+
+	t.Run("concurrent validations", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				data := validationTestSchedule{Name: "name", Periods: []validationTestPeriod{{Start: start, End: start}}}
+				if env, _ := sut(data); env == nil {
+					t.Error("didn't returned the expected envelope")
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("service register", func(t *testing.T) {
+		container := slate.NewServiceContainer()
+		_ = slate.NewConfigServiceRegister().Provide(container)
+		_ = slate.NewLogServiceRegister().Provide(container)
+		_ = NewValidationServiceRegister().Provide(container)
+		_ = container.Add("rule", func() ValidationRule { return maxRule }, ValidationRuleTag)
+		_ = container.Add("struct", func() ValidationStructRule { return rangeRule }, ValidationStructRuleTag)
+		_ = container.Add("other", func() string { return "other" }, ValidationStructRuleTag)
+
+		if list, e := container.Get(ValidationAllStructRulesContainerID); e != nil {
+			t.Errorf("unexpected (%v) error", e)
+		} else if rules, ok := list.([]ValidationStructRule); !ok || len(rules) != 1 {
+			t.Errorf("(%v) unexpected struct rules list", list)
+		}
+
+		instance, e := container.Get(ValidationContainerID)
+		if e != nil {
+			t.Fatalf("unexpected (%v) error", e)
+		}
+		env, _ := instance.(Validator)(validationTestRange{Min: 2, Max: 1})
+		check(t, env, 301, 6, "max", "max", "max must not be lower than min")
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	"github.com/golang/mock/gomock"
@@ -55,6 +58,144 @@ func Test_ValidationUniversalTranslator(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("NewValidationLocalesUniversalTranslator", func(t *testing.T) {
+		t.Run("create", func(t *testing.T) {
+			if sut, e := NewValidationLocalesUniversalTranslator(); e != nil {
+				t.Errorf("unexpected (%v) error", e)
+			} else if sut == nil {
+				t.Errorf("didn't create the desired universal translator")
+			}
+		})
+
+		t.Run("unsupported locale", func(t *testing.T) {
+			prev := ValidationLocales
+			ValidationLocales = []string{"en", "invalid"}
+			defer func() { ValidationLocales = prev }()
+
+			if _, e := NewValidationLocalesUniversalTranslator(); !errors.Is(e, ErrValidationTranslatorNotFound) {
+				t.Errorf("(%v) when expecting (%v)", e, ErrValidationTranslatorNotFound)
+			}
+		})
+
+		t.Run("load the configured locales", func(t *testing.T) {
+			prev := ValidationLocales
+			ValidationLocales = []string{"pt_BR", " es", "EN"}
+			defer func() { ValidationLocales = prev }()
+
+			sut, _ := NewValidationLocalesUniversalTranslator()
+			for _, locale := range []string{"en", "es", "pt_BR"} {
+				if _, found := sut.GetTranslator(locale); !found {
+					t.Errorf("didn't loaded the (%v) locale", locale)
+				}
+			}
+			if sut.GetFallback().Locale() != ValidationLocale {
+				t.Errorf("(%v) fallback when expecting (%v)", sut.GetFallback().Locale(), ValidationLocale)
+			}
+		})
+	})
+}
+
+func Test_validationAcceptLanguage(t *testing.T) {
+	scenarios := []struct {
+		header   string
+		expected []string
+	}{
+		{ // empty header
+			header:   "",
+			expected: nil,
+		},
+		{ // single language
+			header:   "fr",
+			expected: []string{"fr"},
+		},
+		{ // regional locale followed by its language
+			header:   "pt-BR",
+			expected: []string{"pt_BR", "pt"},
+		},
+		{ // quality ordering, wildcard and refused languages
+			header:   "en;q=0.5, *;q=0.1, es-ES;q=0.8, de;q=0, fr",
+			expected: []string{"fr", "es_ES", "es", "en"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		if locales := validationAcceptLanguage(scenario.header); !reflect.DeepEqual(locales, scenario.expected) {
+			t.Errorf("(%v) when expecting (%v)", locales, scenario.expected)
+		}
+	}
+}
+
+func Test_validationField(t *testing.T) {
+	type item struct {
+		Name string `vparam:"3"`
+	}
+	type root struct {
+		Item  item `vparam:"1"`
+		Items []*item
+		Count int `vparam:"2"`
+	}
+
+	scenarios := []struct {
+		segments []string
+		found    bool
+		param    string
+	}{
+		{ // direct field
+			segments: []string{"Count"},
+			found:    true,
+			param:    "2",
+		},
+		{ // nested field
+			segments: []string{"Item", "Name"},
+			found:    true,
+			param:    "3",
+		},
+		{ // indexed container field
+			segments: []string{"Items", "[0]", "Name"},
+			found:    true,
+			param:    "3",
+		},
+		{ // unknown nested field
+			segments: []string{"Item", "Missing"},
+			found:    false,
+		},
+		{ // field of a non-struct field
+			segments: []string{"Count", "Name"},
+			found:    false,
+		},
+		{ // index of a non-container field
+			segments: []string{"Item", "[0]"},
+			found:    false,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		field, found := validationField(reflect.TypeOf(root{}), scenario.segments)
+		switch {
+		case found != scenario.found:
+			t.Errorf("(%v) when expecting (%v) for (%v)", found, scenario.found, scenario.segments)
+		case !found && !reflect.DeepEqual(field, reflect.StructField{}):
+			t.Errorf("(%v) unexpected field for (%v)", field, scenario.segments)
+		case found && field.Tag.Get("vparam") != scenario.param:
+			t.Errorf("(%v) when expecting (%v) for (%v)", field.Tag.Get("vparam"), scenario.param, scenario.segments)
+		}
+	}
+}
+
+func Test_ValidationSetLocale(t *testing.T) {
+	t.Run("nil context", func(t *testing.T) {
+		ValidationSetLocale(nil, "es")
+	})
+
+	t.Run("store the locale", func(t *testing.T) {
+		ctx := &gin.Context{}
+		ValidationSetLocale(ctx, "es")
+
+		if locale, _ := ctx.Get(ValidationLocaleContextField); locale != "es" {
+			t.Errorf("(%v) when expecting (es)", locale)
+		}
+	})
 }
 
 func Test_ValidationTranslator(t *testing.T) {
@@ -119,6 +260,223 @@ func Test_ValidationParser(t *testing.T) {
 		})
 	})
 
+	t.Run("NewValidationRulesParser", func(t *testing.T) {
+		t.Run("nil translator", func(t *testing.T) {
+			parser, e := NewValidationRulesParser(nil, nil)
+			switch {
+			case parser != nil:
+				t.Error("returned a valid reference")
+			case !errors.Is(e, slate.ErrNilPointer):
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("map the rules error codes", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			translator := NewMockTranslator(ctrl)
+			rule, _ := NewValidationRule("even", 300, func(validator.FieldLevel) bool { return true }, nil)
+
+			p, e := NewValidationRulesParser(translator, []ValidationRule{nil, rule})
+			switch {
+			case e != nil:
+				t.Errorf("return the (%v) error", e)
+			case p.translator != translator:
+				t.Error("didn't stored the translator reference")
+			case p.mapper["even"] != 300:
+				t.Errorf("(%v) when expecting (300)", p.mapper["even"])
+			}
+		})
+	})
+
+	t.Run("NewValidationConfigParser", func(t *testing.T) {
+		t.Run("nil config", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			parser, e := NewValidationConfigParser(nil, slate.NewLog(), NewMockTranslator(ctrl), nil)
+			switch {
+			case parser != nil:
+				t.Error("returned a valid reference")
+			case !errors.Is(e, slate.ErrNilPointer):
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil logger", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			parser, e := NewValidationConfigParser(slate.NewConfig(), nil, NewMockTranslator(ctrl), nil)
+			switch {
+			case parser != nil:
+				t.Error("returned a valid reference")
+			case !errors.Is(e, slate.ErrNilPointer):
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil translator", func(t *testing.T) {
+			parser, e := NewValidationConfigParser(slate.NewConfig(), slate.NewLog(), nil, nil)
+			switch {
+			case parser != nil:
+				t.Error("returned a valid reference")
+			case !errors.Is(e, slate.ErrNilPointer):
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("map the rules error codes", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			translator := NewMockTranslator(ctrl)
+			rule, _ := NewValidationRule("even", 300, nil, nil)
+
+			p, e := NewValidationConfigParser(slate.NewConfig(), slate.NewLog(), translator, []ValidationRule{nil, rule})
+			switch {
+			case e != nil:
+				t.Errorf("return the (%v) error", e)
+			case p.translator != translator:
+				t.Error("didn't stored the translator reference")
+			case p.Code("even") != 300:
+				t.Errorf("(%v) when expecting (300)", p.Code("even"))
+			}
+		})
+	})
+
+	t.Run("Code", func(t *testing.T) {
+		configured := func(ctrl *gomock.Controller, codes interface{}, fallback interface{}) *slate.Config {
+			partial := slate.ConfigPartial{}
+			if codes != nil {
+				_, _ = partial.Set("slate.api.validation.codes", codes)
+			}
+			if fallback != nil {
+				_, _ = partial.Set("slate.api.validation.fallback_code", fallback)
+			}
+			supplier := NewMockConfigSupplier(ctrl)
+			supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+			config := slate.NewConfig()
+			_ = config.AddSupplier("id", 0, supplier)
+			return config
+		}
+
+		t.Run("default codes", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sut, _ := NewValidationConfigParser(slate.NewConfig(), slate.NewLog(), NewMockTranslator(ctrl), nil)
+
+			scenarios := map[string]int{"required": 104, "unique": 115, "excluded_if": 116, "ulid": 129, "luhn_checksum": 159}
+			for tag, expected := range scenarios {
+				if code := sut.Code(tag); code != expected {
+					t.Errorf("(%v) when expecting (%v) for the (%v) tag", code, expected, tag)
+				}
+			}
+		})
+
+		t.Run("configured codes", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := configured(ctrl, slate.ConfigPartial{"required": 900, "even": 901}, nil)
+			rule, _ := NewValidationRule("even", 300, nil, nil)
+			sut, e := NewValidationConfigParser(config, slate.NewLog(), NewMockTranslator(ctrl), []ValidationRule{rule})
+
+			switch {
+			case e != nil:
+				t.Errorf("unexpected (%v) error", e)
+			case sut.Code("required") != 900:
+				t.Errorf("(%v) when expecting (900)", sut.Code("required"))
+			case sut.Code("even") != 901:
+				t.Errorf("(%v) when expecting (901)", sut.Code("even"))
+			case sut.Code("gt") != 89:
+				t.Errorf("(%v) when expecting (89)", sut.Code("gt"))
+			}
+		})
+
+		t.Run("invalid configured codes", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := configured(ctrl, slate.ConfigPartial{"required": "string"}, nil)
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(ValidationLogChannel, slate.ERROR, ValidationLogCodesErrorMessage, gomock.Any()).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			if sut, e := NewValidationConfigParser(config, logger, NewMockTranslator(ctrl), nil); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("invalid configured fallback code", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := configured(ctrl, nil, "string")
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(ValidationLogChannel, slate.ERROR, ValidationLogCodesErrorMessage, gomock.Any()).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+
+			if sut, e := NewValidationConfigParser(config, logger, NewMockTranslator(ctrl), nil); sut != nil {
+				t.Error("returned an unexpected valid reference")
+			} else if !errors.Is(e, slate.ErrConversion) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+			}
+		})
+
+		t.Run("signal the unmapped tags once", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := configured(ctrl, nil, 999)
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(ValidationLogChannel, ValidationLogUnmappedLevel, ValidationLogUnmappedMessage, slate.LogContext{"tag": "unknown", "code": 999}).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+			sut, _ := NewValidationConfigParser(config, logger, NewMockTranslator(ctrl), nil)
+
+			for i := 0; i < 2; i++ {
+				if code := sut.Code("unknown"); code != 999 {
+					t.Errorf("(%v) when expecting (999)", code)
+				}
+			}
+		})
+
+		t.Run("use the updated codes", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := configured(ctrl, slate.ConfigPartial{"required": 900}, 999)
+			newPartial := slate.ConfigPartial{}
+			_, _ = newPartial.Set("slate.api.validation.codes", slate.ConfigPartial{"required": 910})
+			_, _ = newPartial.Set("slate.api.validation.fallback_code", 990)
+			newSupplier := NewMockConfigSupplier(ctrl)
+			newSupplier.EXPECT().Get("").Return(newPartial, nil).AnyTimes()
+			logWriter := NewMockLogWriter(ctrl)
+			logWriter.EXPECT().Signal(ValidationLogChannel, ValidationLogUnmappedLevel, ValidationLogUnmappedMessage, slate.LogContext{"tag": "unknown", "code": 999}).Times(1)
+			logWriter.EXPECT().Signal(ValidationLogChannel, ValidationLogUnmappedLevel, ValidationLogUnmappedMessage, slate.LogContext{"tag": "unknown", "code": 990}).Times(1)
+			logger := slate.NewLog()
+			_ = logger.AddWriter("id", logWriter)
+			sut, _ := NewValidationConfigParser(config, logger, NewMockTranslator(ctrl), nil)
+			_ = sut.Code("unknown")
+
+			_ = config.AddSupplier("id2", 1, newSupplier)
+
+			switch {
+			case sut.Code("required") != 910:
+				t.Errorf("(%v) when expecting (910)", sut.Code("required"))
+			case sut.Code("unknown") != 990:
+				t.Errorf("(%v) when expecting (990)", sut.Code("unknown"))
+			}
+		})
+	})
+
 	t.Run("Parse", func(t *testing.T) {
 		t.Run("nil value", func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -204,7 +562,8 @@ func Test_ValidationParser(t *testing.T) {
 			expected := `strconv.Atoi: parsing "string": invalid syntax`
 			translator := NewMockTranslator(ctrl)
 			fieldError := NewMockFieldError(ctrl)
-			fieldError.EXPECT().StructField().Return("Field").Times(1)
+			fieldError.EXPECT().StructNamespace().Return("Field").Times(1)
+			fieldError.EXPECT().Namespace().Return("Field").Times(1)
 
 			sut, _ := NewValidationParser(translator)
 
@@ -230,8 +589,10 @@ func Test_ValidationParser(t *testing.T) {
 			expected := "c:89"
 			translator := NewMockTranslator(ctrl)
 			fieldError := NewMockFieldError(ctrl)
-			fieldError.EXPECT().StructField().Return("Field").Times(1)
+			fieldError.EXPECT().StructNamespace().Return("Field").Times(1)
+			fieldError.EXPECT().Namespace().Return("Field").Times(1)
 			fieldError.EXPECT().Translate(translator).Return(errMsg).Times(1)
+			fieldError.EXPECT().Field().Return("Field").Times(1)
 			fieldError.EXPECT().Tag().Return("gt").Times(1)
 
 			sut, _ := NewValidationParser(translator)
@@ -260,8 +621,10 @@ func Test_ValidationParser(t *testing.T) {
 			expected := "c:0"
 			translator := NewMockTranslator(ctrl)
 			fieldError := NewMockFieldError(ctrl)
-			fieldError.EXPECT().StructField().Return("Field").Times(1)
+			fieldError.EXPECT().StructNamespace().Return("Field").Times(1)
+			fieldError.EXPECT().Namespace().Return("Field").Times(1)
 			fieldError.EXPECT().Translate(translator).Return(errMsg).Times(1)
+			fieldError.EXPECT().Field().Return("Field").Times(1)
 			fieldError.EXPECT().Tag().Return("unrecognized").Times(1)
 
 			sut, _ := NewValidationParser(translator)
@@ -288,8 +651,10 @@ func Test_ValidationParser(t *testing.T) {
 			errMsg := "error message"
 			translator := NewMockTranslator(ctrl)
 			fieldError := NewMockFieldError(ctrl)
-			fieldError.EXPECT().StructField().Return("Field").Times(1)
+			fieldError.EXPECT().StructNamespace().Return("Field").Times(1)
+			fieldError.EXPECT().Namespace().Return("Field").Times(1)
 			fieldError.EXPECT().Translate(translator).Return(errMsg).Times(1)
+			fieldError.EXPECT().Field().Return("Field").Times(1)
 			fieldError.EXPECT().Tag().Return("gt").Times(1)
 
 			sut, _ := NewValidationParser(translator)
@@ -320,8 +685,10 @@ func Test_ValidationParser(t *testing.T) {
 			errMsg := "error message"
 			translator := NewMockTranslator(ctrl)
 			fieldError := NewMockFieldError(ctrl)
-			fieldError.EXPECT().StructField().Return("Field").Times(1)
+			fieldError.EXPECT().StructNamespace().Return("Field").Times(1)
+			fieldError.EXPECT().Namespace().Return("Field").Times(1)
 			fieldError.EXPECT().Translate(translator).Return(errMsg).Times(1)
+			fieldError.EXPECT().Field().Return("Field").Times(1)
 			fieldError.EXPECT().Tag().Return(mappedErrorName).Times(1)
 
 			sut, _ := NewValidationParser(translator)
@@ -400,6 +767,143 @@ func Test_Validator(t *testing.T) {
 				t.Error("didn't return the expected validation instance")
 			}
 		})
+
+		t.Run("error registering the translations", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expected := fmt.Errorf("error message")
+			translator := NewMockTranslator(ctrl)
+			translator.
+				EXPECT().
+				Add(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(expected).
+				Times(1)
+			parser, _ := NewValidationParser(translator)
+
+			if _, e := NewValidator(translator, parser); !errors.Is(e, expected) {
+				t.Errorf("(%v) when expecting (%v)", e, expected)
+			}
+		})
+	})
+
+	t.Run("NewValidatorWithLocales", func(t *testing.T) {
+		t.Run("nil translator", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			parser, _ := NewValidationParser(NewMockTranslator(ctrl))
+
+			if _, e := NewValidatorWithLocales(nil, parser, ut.New(en.New())); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil parser", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			if _, e := NewValidatorWithLocales(NewMockTranslator(ctrl), nil, ut.New(en.New())); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil universal translator", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			translator := NewMockTranslator(ctrl)
+			parser, _ := NewValidationParser(translator)
+
+			if _, e := NewValidatorWithLocales(translator, parser, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("construct", func(t *testing.T) {
+			universal, _ := NewValidationLocalesUniversalTranslator()
+			translator, _ := NewValidationTranslator(universal)
+			parser, _ := NewValidationParser(translator)
+
+			if check, e := NewValidatorWithLocales(translator, parser, universal); e != nil {
+				t.Errorf("return the unexpected error (%v)", e)
+			} else if check == nil {
+				t.Error("didn't return the expected validation instance")
+			}
+		})
+	})
+
+	t.Run("NewValidatorWithRules", func(t *testing.T) {
+		t.Run("nil translator", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			parser, _ := NewValidationParser(NewMockTranslator(ctrl))
+
+			if _, e := NewValidatorWithRules(nil, parser, ut.New(en.New()), nil, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil parser", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			if _, e := NewValidatorWithRules(NewMockTranslator(ctrl), nil, ut.New(en.New()), nil, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("nil universal translator", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			translator := NewMockTranslator(ctrl)
+			parser, _ := NewValidationParser(translator)
+
+			if _, e := NewValidatorWithRules(translator, parser, nil, nil, nil); !errors.Is(e, slate.ErrNilPointer) {
+				t.Errorf("(%v) when expecting (%v)", e, slate.ErrNilPointer)
+			}
+		})
+
+		t.Run("construct", func(t *testing.T) {
+			universal, _ := NewValidationLocalesUniversalTranslator()
+			translator, _ := NewValidationTranslator(universal)
+			parser, _ := NewValidationParser(translator)
+
+			if check, e := NewValidatorWithRules(translator, parser, universal, nil, nil); e != nil {
+				t.Errorf("return the unexpected error (%v)", e)
+			} else if check == nil {
+				t.Error("didn't return the expected validation instance")
+			}
+		})
+
+		t.Run("construct with already used translators", func(t *testing.T) {
+			universal, _ := NewValidationLocalesUniversalTranslator()
+			translator, _ := NewValidationTranslator(universal)
+			parser, _ := NewValidationParser(translator)
+			_, _ = NewValidatorWithRules(translator, parser, universal, nil, nil)
+			data := struct {
+				Field int `json:"field" xml:"xmlField" validate:"required"`
+			}{}
+
+			check, e := NewValidatorWithRules(translator, parser, universal, nil, nil)
+			switch {
+			case e != nil:
+				t.Errorf("return the unexpected error (%v)", e)
+			case check == nil:
+				t.Error("didn't return the expected validation instance")
+			default:
+				env, _ := check(data)
+				if env == nil || env.Status.Errors[0].Message != "field is a required field" {
+					t.Errorf("unexpected (%v) envelope", env)
+				}
+				env, _ = check(data, ValidationWithFieldTag(ValidationXMLFieldTag))
+				if env == nil || env.Status.Errors[0].Message != "xmlField is a required field" {
+					t.Errorf("unexpected (%v) envelope", env)
+				}
+			}
+		})
 	})
 
 	t.Run("call", func(t *testing.T) {
@@ -506,7 +1010,7 @@ func Test_Validator(t *testing.T) {
 			}{Field1: 11, Field2: 11}
 			errMsg := "error message"
 			expected := NewEnvelope(http.StatusBadRequest, nil, nil)
-			expected.AddError(NewEnvelopeStatusError(92, errMsg).SetParam(1))
+			expected.AddError(NewEnvelopeStatusError(92, errMsg).SetParam(1).SetField("Field1").SetPath("Field1"))
 			translator := NewMockTranslator(ctrl)
 			translator.
 				EXPECT().
@@ -540,6 +1044,479 @@ func Test_Validator(t *testing.T) {
 	})
 }
 
+func Test_Validator_locale(t *testing.T) {
+	data := struct {
+		Field int `validate:"required" vparam:"1"`
+	}{}
+
+	prev := ValidationLocales
+	ValidationLocales = []string{"en", "es", "pt", "pt_BR"}
+	defer func() { ValidationLocales = prev }()
+
+	universal, _ := NewValidationLocalesUniversalTranslator()
+	translator, _ := NewValidationTranslator(universal)
+	parser, _ := NewValidationParser(translator)
+	sut, e := NewValidatorWithLocales(translator, parser, universal)
+	if e != nil {
+		t.Fatalf("unexpected (%v) error", e)
+	}
+
+	message := func(env *Envelope) string {
+		if env == nil || len(env.Status.Errors) != 1 {
+			t.Fatalf("unexpected (%v) envelope", env)
+		}
+		return env.Status.Errors[0].Message
+	}
+	request := func(header string) *gin.Context {
+		ctx := &gin.Context{}
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+		ctx.Request.Header.Set(ValidationAcceptLanguageHeader, header)
+		return ctx
+	}
+
+	english, _ := sut(data)
+	spanish, _ := sut(data, ValidationWithLocale("es"))
+	brazilian, _ := sut(data, ValidationWithLocale("pt_BR"))
+	if message(english) == message(spanish) || message(english) == message(brazilian) {
+		t.Fatalf("(%v, %v, %v) messages are not translated", message(english), message(spanish), message(brazilian))
+	}
+
+	scenarios := []struct {
+		name     string
+		opts     []ValidationOption
+		expected string
+	}{
+		{
+			name:     "default locale without context",
+			opts:     []ValidationOption{ValidationWithContext(nil)},
+			expected: message(english),
+		},
+		{
+			name:     "negotiated locale",
+			opts:     []ValidationOption{ValidationWithContext(request("fr;q=0.9, es"))},
+			expected: message(spanish),
+		},
+		{
+			name:     "negotiated regional locale",
+			opts:     []ValidationOption{ValidationWithContext(request("pt-BR, en;q=0.5"))},
+			expected: message(brazilian),
+		},
+		{
+			name:     "default locale on unsupported languages",
+			opts:     []ValidationOption{ValidationWithContext(request("de, fr"))},
+			expected: message(english),
+		},
+		{
+			name: "context locale overrides the negotiated locale",
+			opts: []ValidationOption{ValidationWithContext(func() *gin.Context {
+				ctx := request("en")
+				ValidationSetLocale(ctx, "es")
+				return ctx
+			}())},
+			expected: message(spanish),
+		},
+		{
+			name:     "explicit locale overrides the context",
+			opts:     []ValidationOption{ValidationWithContext(request("es")), ValidationWithLocale("pt_BR")},
+			expected: message(brazilian),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if env, _ := sut(data, scenario.opts...); message(env) != scenario.expected {
+				t.Errorf("(%v) when expecting (%v)", message(env), scenario.expected)
+			}
+		})
+	}
+}
+
+type validationTestItem struct {
+	Qty int `validate:"gt=0" vparam:"3"`
+}
+
+type validationTestAddress struct {
+	Zip string `validate:"required" vparam:"2"`
+}
+
+type validationTestRequest struct {
+	Name    string                        `validate:"required" vparam:"1"`
+	Address *validationTestAddress        `validate:"required"`
+	Items   []validationTestItem          `validate:"dive"`
+	Stock   map[string]validationTestItem `validate:"dive"`
+	Tags    []int                         `validate:"dive,gt=0" vparam:"4"`
+}
+
+func Test_validationNamespace(t *testing.T) {
+	scenarios := []struct {
+		namespace string
+		expected  []string
+		path      string
+	}{
+		{namespace: "", expected: nil, path: ""},
+		{namespace: "Field", expected: []string{"Field"}, path: "Field"},
+		{namespace: "Root.Address.Zip", expected: []string{"Root", "Address", "Zip"}, path: "Root.Address.Zip"},
+		{namespace: "Root.Items[3].Qty", expected: []string{"Root", "Items", "[3]", "Qty"}, path: "Root.Items[3].Qty"},
+		{namespace: "Root.Stock[a.b].Qty", expected: []string{"Root", "Stock", "[a.b]", "Qty"}, path: "Root.Stock[a.b].Qty"},
+		{namespace: "Root.Tags[0]", expected: []string{"Root", "Tags", "[0]"}, path: "Root.Tags[0]"},
+	}
+
+	for _, scenario := range scenarios {
+		segments := validationNamespace(scenario.namespace)
+		if !reflect.DeepEqual(segments, scenario.expected) {
+			t.Errorf("(%v) when expecting (%v)", segments, scenario.expected)
+		} else if path := validationPath(segments); path != scenario.path {
+			t.Errorf("(%v) when expecting (%v)", path, scenario.path)
+		}
+	}
+}
+
+func Test_Validator_nested(t *testing.T) {
+	universal, _ := NewValidationLocalesUniversalTranslator()
+	translator, _ := NewValidationTranslator(universal)
+	parser, _ := NewValidationParser(translator)
+	sut, _ := NewValidatorWithRules(translator, parser, universal, nil, nil)
+
+	t.Run("non struct value", func(t *testing.T) {
+		if _, e := sut(map[string]string{}); e == nil {
+			t.Error("didn't returned the expected error")
+		}
+	})
+
+	t.Run("nested errors params and paths", func(t *testing.T) {
+		data := &validationTestRequest{
+			Name:    "name",
+			Address: &validationTestAddress{},
+			Items:   []validationTestItem{{Qty: 1}, {Qty: 0}},
+			Stock:   map[string]validationTestItem{"item": {Qty: 0}},
+			Tags:    []int{1, 0},
+		}
+		expected := map[string]int{
+			"Address.Zip":     2,
+			"Items[1].Qty":    3,
+			"Stock[item].Qty": 3,
+			"Tags[1]":         4,
+		}
+
+		env, e := sut(data)
+		if e != nil {
+			t.Fatalf("unexpected (%v) error", e)
+		} else if env == nil || len(env.Status.Errors) != len(expected) {
+			t.Fatalf("unexpected (%v) envelope", env)
+		}
+		for _, err := range env.Status.Errors {
+			if param, ok := expected[err.Path]; !ok {
+				t.Errorf("unexpected (%v) error path", err.Path)
+			} else if err.Param != param {
+				t.Errorf("(%v) param of (%v) when expecting (%v)", err.Param, err.Path, param)
+			}
+		}
+	})
+
+	t.Run("anonymous struct root", func(t *testing.T) {
+		data := struct {
+			Item validationTestItem
+		}{}
+
+		env, _ := sut(data)
+		if env == nil || len(env.Status.Errors) != 1 {
+			t.Fatalf("unexpected (%v) envelope", env)
+		} else if err := env.Status.Errors[0]; err.Path != "Item.Qty" || err.Param != 3 {
+			t.Errorf("(%v, %v) when expecting (Item.Qty, 3)", err.Path, err.Param)
+		}
+	})
+}
+
+func Test_validationTagName(t *testing.T) {
+	scenarios := []struct {
+		tag      string
+		field    reflect.StructField
+		expected string
+	}{
+		{tag: "json", field: reflect.StructField{Name: "Field"}, expected: ""},
+		{tag: "json", field: reflect.StructField{Name: "Field", Tag: `json:"field,omitempty"`}, expected: "field"},
+		{tag: "json", field: reflect.StructField{Name: "Field", Tag: `json:"-"`}, expected: ""},
+		{tag: "json", field: reflect.StructField{Name: "Field", Tag: `json:",omitempty"`}, expected: ""},
+		{tag: "xml", field: reflect.StructField{Name: "Field", Tag: `xml:"parent>field"`}, expected: "field"},
+		{tag: "xml", field: reflect.StructField{Name: "Field", Tag: `xml:"field,attr"`}, expected: "field"},
+	}
+
+	for _, scenario := range scenarios {
+		if name := validationTagName(scenario.tag)(scenario.field); name != scenario.expected {
+			t.Errorf("(%v) when expecting (%v)", name, scenario.expected)
+		}
+	}
+}
+
+func Test_Validator_fieldNames(t *testing.T) {
+	type address struct {
+		Zip string `json:"zip_code" xml:"zipCode" validate:"required"`
+	}
+	data := struct {
+		FirstName string   `json:"first_name" xml:"firstName" validate:"required" vparam:"1"`
+		Address   *address `json:"address" xml:"address"`
+		Even      int      `json:"even" xml:"even" validate:"even"`
+	}{Address: &address{}, Even: 1}
+
+	rule, _ := NewValidationRule("even", 200, func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}, map[string]string{"en": "{0} must be even"})
+	universal, _ := NewValidationLocalesUniversalTranslator()
+	translator, _ := NewValidationTranslator(universal)
+	parser, _ := NewValidationRulesParser(translator, []ValidationRule{rule})
+	sut, _ := NewValidatorWithRules(translator, parser, universal, []ValidationRule{rule}, nil)
+
+	request := func(accept string) *gin.Context {
+		ctx := &gin.Context{}
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+		ctx.Request.Header.Set("Accept", accept)
+		return ctx
+	}
+	json := []EnvelopeStatusError{
+		{Field: "first_name", Path: "first_name", Message: "first_name is a required field"},
+		{Field: "zip_code", Path: "address.zip_code", Message: "zip_code is a required field"},
+		{Field: "even", Path: "even", Message: "even must be even"},
+	}
+	xml := []EnvelopeStatusError{
+		{Field: "firstName", Path: "firstName", Message: "firstName is a required field"},
+		{Field: "zipCode", Path: "address.zipCode", Message: "zipCode is a required field"},
+		{Field: "even", Path: "even", Message: "even must be even"},
+	}
+
+	scenarios := []struct {
+		name     string
+		opts     []ValidationOption
+		expected []EnvelopeStatusError
+	}{
+		{name: "default field names", expected: json},
+		{name: "json negotiated request", opts: []ValidationOption{ValidationWithContext(request(gin.MIMEJSON))}, expected: json},
+		{name: "xml negotiated request", opts: []ValidationOption{ValidationWithContext(request(gin.MIMEXML))}, expected: xml},
+		{name: "request without accept header", opts: []ValidationOption{ValidationWithContext(request(""))}, expected: json},
+		{name: "forced field tag", opts: []ValidationOption{ValidationWithFieldTag("xml")}, expected: xml},
+		{name: "unknown field tag", opts: []ValidationOption{ValidationWithFieldTag("yaml")}, expected: json},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			env, e := sut(data, scenario.opts...)
+			if e != nil {
+				t.Fatalf("unexpected (%v) error", e)
+			} else if env == nil || len(env.Status.Errors) != len(scenario.expected) {
+				t.Fatalf("unexpected (%v) envelope", env)
+			}
+			for i, expected := range scenario.expected {
+				err := env.Status.Errors[i]
+				if err.Field != expected.Field || err.Path != expected.Path || err.Message != expected.Message {
+					t.Errorf("(%v, %v, %v) when expecting (%v, %v, %v)", err.Field, err.Path, err.Message, expected.Field, expected.Path, expected.Message)
+				}
+			}
+		})
+	}
+}
+
+type validationTestMessage struct {
+	Name  string `json:"name" validate:"required,min=3" vmsg:"required=Please tell us your name;min={field} needs {param} characters, got {value}"`
+	Email string `json:"email" xml:"mail" validate:"required,email" vmsg:"*=Please enter a valid email"`
+	Age   int    `json:"age" validate:"gte=18"`
+	Nick  string `json:"nick" validate:"required,max=5" vmsg:"max=at most {param} characters\\; got {value};min=5 chars"`
+	Owner struct {
+		Name string `json:"name" validate:"required"`
+	} `json:"owner"`
+	Pet struct {
+		Name string `json:"name" validate:"required"`
+	} `json:"pet"`
+}
+
+func Test_Validator_messages(t *testing.T) {
+	prev := ValidationLocales
+	ValidationLocales = []string{"en", "es"}
+	defer func() { ValidationLocales = prev }()
+
+	messages := func(gte string) slate.ConfigPartial {
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.validation.messages", slate.ConfigPartial{
+			"en": slate.ConfigPartial{
+				"gte": gte,
+				"validationTestMessage": slate.ConfigPartial{
+					"Nick":  slate.ConfigPartial{"required": "Pick a nickname"},
+					"Email": slate.ConfigPartial{"required": "The email is mandatory"},
+					"Owner": slate.ConfigPartial{
+						"Name": slate.ConfigPartial{"required": "The owner needs a name"},
+					},
+				},
+			},
+			"es": slate.ConfigPartial{
+				"gte": "{field} debe ser al menos {param}",
+			},
+		})
+		return partial
+	}
+	valid := func() *validationTestMessage {
+		data := &validationTestMessage{Name: "john", Email: "john@example.com", Age: 20, Nick: "jj"}
+		data.Owner.Name = "owner"
+		data.Pet.Name = "pet"
+		return data
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	supplier := NewMockConfigSupplier(ctrl)
+	supplier.EXPECT().Get("").Return(messages("{field} must be at least {param}"), nil).AnyTimes()
+	config := slate.NewConfig()
+	_ = config.AddSupplier("id", 0, supplier)
+
+	universal, _ := NewValidationLocalesUniversalTranslator()
+	translator, _ := NewValidationTranslator(universal)
+	parser, e := NewValidationConfigParser(config, slate.NewLog(), translator, nil)
+	if e != nil {
+		t.Fatalf("unexpected (%v) error", e)
+	}
+	sut, _ := NewValidatorWithRules(translator, parser, universal, nil, nil)
+
+	t.Run("select the message template", func(t *testing.T) {
+		scenarios := []struct {
+			name     string
+			change   func(data *validationTestMessage)
+			opts     []ValidationOption
+			expected string
+		}{
+			{
+				name:     "field tag template",
+				change:   func(data *validationTestMessage) { data.Name = "" },
+				expected: "Please tell us your name",
+			},
+			{
+				name:     "field tag template with placeholders",
+				change:   func(data *validationTestMessage) { data.Name = "jo" },
+				expected: "name needs 3 characters, got jo",
+			},
+			{
+				name:     "field default template",
+				change:   func(data *validationTestMessage) { data.Email = "email" },
+				expected: "Please enter a valid email",
+			},
+			{
+				name:     "configured field template takes precedence",
+				change:   func(data *validationTestMessage) { data.Email = "" },
+				expected: "The email is mandatory",
+			},
+			{
+				name:     "configured field template",
+				change:   func(data *validationTestMessage) { data.Nick = "" },
+				expected: "Pick a nickname",
+			},
+			{
+				name:     "configured field template of the xml field names",
+				change:   func(data *validationTestMessage) { data.Email = "" },
+				opts:     []ValidationOption{ValidationWithFieldTag(ValidationXMLFieldTag)},
+				expected: "The email is mandatory",
+			},
+			{
+				name:     "configured nested field template",
+				change:   func(data *validationTestMessage) { data.Owner.Name = "" },
+				expected: "The owner needs a name",
+			},
+			{
+				name:     "nested field with the name of a configured field",
+				change:   func(data *validationTestMessage) { data.Pet.Name = "" },
+				expected: "name is a required field",
+			},
+			{
+				name:     "field tag template with an escaped separator",
+				change:   func(data *validationTestMessage) { data.Nick = "nickname" },
+				expected: "at most 5 characters; got nickname",
+			},
+			{
+				name:     "configured tag template",
+				change:   func(data *validationTestMessage) { data.Age = 10 },
+				expected: "age must be at least 18",
+			},
+			{
+				name:     "configured locale tag template",
+				change:   func(data *validationTestMessage) { data.Age = 10 },
+				opts:     []ValidationOption{ValidationWithLocale("es")},
+				expected: "age debe ser al menos 18",
+			},
+			{
+				name:     "default translation without locale templates",
+				change:   func(data *validationTestMessage) { data.Nick = "" },
+				opts:     []ValidationOption{ValidationWithLocale("es")},
+				expected: "nick es un campo requerido",
+			},
+		}
+
+		for _, scenario := range scenarios {
+			t.Run(scenario.name, func(t *testing.T) {
+				data := valid()
+				scenario.change(data)
+
+				if env, _ := sut(data, scenario.opts...); env == nil || len(env.Status.Errors) != 1 {
+					t.Errorf("unexpected (%v) envelope", env)
+				} else if env.Status.Errors[0].Message != scenario.expected {
+					t.Errorf("(%v) when expecting (%v)", env.Status.Errors[0].Message, scenario.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("translate the field errors with the templates", func(t *testing.T) {
+		validate := validator.New()
+		trans := ut.New(en.New()).GetFallback()
+		defaults := &validationShadowTranslator{Translator: trans}
+		_ = validationLocales["en"].translations(validate, defaults)
+		if e := validationRegisterTemplates(validate, parser, []string{"gte", "required"}, []ut.Translator{trans}, []ut.Translator{defaults}); e != nil {
+			t.Fatalf("unexpected (%v) error", e)
+		}
+		data := valid()
+		data.Age = 10
+		data.Nick = ""
+		data.Pet.Name = ""
+		expected := []string{"Age must be at least 18", "Pick a nickname", "Name is a required field"}
+
+		errs, _ := validate.Struct(data).(validator.ValidationErrors)
+		if len(errs) != len(expected) {
+			t.Fatalf("unexpected (%v) errors", errs)
+		}
+		for i, e := range errs {
+			if message := e.Translate(trans); message != expected[i] {
+				t.Errorf("(%v) when expecting (%v)", message, expected[i])
+			}
+		}
+	})
+
+	t.Run("use the updated templates", func(t *testing.T) {
+		newSupplier := NewMockConfigSupplier(ctrl)
+		newSupplier.EXPECT().Get("").Return(messages("{field} is too young"), nil).AnyTimes()
+		_ = config.AddSupplier("id2", 1, newSupplier)
+		data := valid()
+		data.Age = 10
+
+		if env, _ := sut(data); env == nil || env.Status.Errors[0].Message != "age is too young" {
+			t.Errorf("unexpected (%v) envelope", env)
+		}
+	})
+
+	t.Run("invalid configured templates", func(t *testing.T) {
+		partial := slate.ConfigPartial{}
+		_, _ = partial.Set("slate.api.validation.messages", slate.ConfigPartial{"en": slate.ConfigPartial{"gte": 12}})
+		supplier := NewMockConfigSupplier(ctrl)
+		supplier.EXPECT().Get("").Return(partial, nil).AnyTimes()
+		config := slate.NewConfig()
+		_ = config.AddSupplier("id", 0, supplier)
+		logWriter := NewMockLogWriter(ctrl)
+		logWriter.EXPECT().Signal(ValidationLogChannel, slate.ERROR, ValidationLogMessagesErrorMessage, gomock.Any()).Times(1)
+		logger := slate.NewLog()
+		_ = logger.AddWriter("id", logWriter)
+
+		if sut, e := NewValidationConfigParser(config, logger, translator, nil); sut != nil {
+			t.Error("returned an unexpected valid reference")
+		} else if !errors.Is(e, slate.ErrConversion) {
+			t.Errorf("(%v) when expecting (%v)", e, slate.ErrConversion)
+		}
+	})
+}
+
 func Test_ValidationServiceRegister(t *testing.T) {
 	t.Run("NewValidationServiceRegister", func(t *testing.T) {
 		t.Run("create", func(t *testing.T) {
@@ -583,6 +1560,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 				t.Errorf("no parser instance : %v", sut)
 			case !container.Has(ValidationContainerID):
 				t.Errorf("no trnalsator creator : %v", sut)
+			case !container.Has(ValidationOptionsContainerID):
+				t.Errorf("no options validator : %v", sut)
 			}
 		})
 
@@ -591,6 +1570,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 			defer ctrl.Finish()
 
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 
 			sut, e := container.Get(ValidationUniversalTranslatorContainerID)
@@ -614,6 +1595,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 
 			expected := fmt.Errorf("error message")
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 			_ = container.Add(ValidationUniversalTranslatorContainerID, func() (*ut.UniversalTranslator, error) {
 				return nil, expected
@@ -631,6 +1614,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 			defer ctrl.Finish()
 
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 
 			sut, e := container.Get(ValidationTranslatorContainerID)
@@ -654,6 +1639,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 
 			expected := fmt.Errorf("error message")
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 			_ = container.Add(ValidationTranslatorContainerID, func() (ut.Translator, error) {
 				return nil, expected
@@ -671,6 +1658,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 			defer ctrl.Finish()
 
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 
 			sut, e := container.Get(ValidationParserContainerID)
@@ -694,6 +1683,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 
 			expected := fmt.Errorf("error message")
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 			_ = container.Add(ValidationTranslatorContainerID, func() (ut.Translator, error) {
 				return nil, expected
@@ -712,6 +1703,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 
 			expected := fmt.Errorf("error message")
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 			_ = container.Add(ValidationParserContainerID, func() (*ValidationParser, error) {
 				return nil, expected
@@ -729,6 +1722,8 @@ func Test_ValidationServiceRegister(t *testing.T) {
 			defer ctrl.Finish()
 
 			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
 			_ = NewValidationServiceRegister().Provide(container)
 
 			sut, e := container.Get(ValidationContainerID)
@@ -745,5 +1740,26 @@ func Test_ValidationServiceRegister(t *testing.T) {
 				}
 			}
 		})
+
+		t.Run("retrieving options validator", func(t *testing.T) {
+			container := slate.NewServiceContainer()
+			_ = slate.NewConfigServiceRegister().Provide(container)
+			_ = slate.NewLogServiceRegister().Provide(container)
+			_ = NewValidationServiceRegister().Provide(container)
+
+			sut, e := container.Get(ValidationOptionsContainerID)
+			switch {
+			case e != nil:
+				t.Errorf("unexpected error (%v)", e)
+			case sut == nil:
+				t.Error("didn't returned a reference to service")
+			default:
+				switch sut.(type) {
+				case ValidatorWithOptions:
+				default:
+					t.Error("didn't returned the options validator")
+				}
+			}
+		})
 	})
 }